@@ -0,0 +1,79 @@
+package property
+
+import (
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+// An Action is a button shown in the value column of an Actions property.
+type Action struct {
+	// Label is the button text.
+	Label string
+
+	// Do is called when the button is clicked.
+	Do func()
+}
+
+// Actions is a property widget showing a row of buttons, each invoking a
+// function when clicked. It turns a List into a complete inspector, with
+// actions like "Reset" or "Recompute" next to the values they apply to.
+type Actions struct {
+	Editable bool
+
+	actions []Action
+	clicks  []widget.Clickable
+	listed
+}
+
+// NewActions creates an Actions property showing one button per action, from
+// left to right.
+func NewActions(actions ...Action) *Actions {
+	return &Actions{
+		Editable: true,
+		actions:  actions,
+		clicks:   make([]widget.Clickable, len(actions)),
+	}
+}
+
+// NewAction creates an Actions property showing a single button.
+func NewAction(label string, do func()) *Actions {
+	return NewActions(Action{Label: label, Do: do})
+}
+
+func (a *Actions) Layout(th *material.Theme, pgtx, gtx C) D {
+	for i := range a.actions {
+		for a.clicks[i].Clicked() {
+			if a.Editable && a.actions[i].Do != nil {
+				a.actions[i].Do()
+			}
+		}
+	}
+
+	if !a.Editable {
+		gtx = gtx.Disabled()
+	}
+
+	s := a.listStyle(th)
+	children := make([]layout.FlexChild, len(a.actions))
+	for i := range a.actions {
+		i := i
+		children[i] = layout.Flexed(1, func(gtx C) D {
+			gtx.Constraints.Min = gtx.Constraints.Max
+			return layout.UniformInset(unit.Dp(2)).Layout(gtx, func(gtx C) D {
+				gtx.Constraints.Min = gtx.Constraints.Max
+				btn := material.Button(th, &a.clicks[i], a.actions[i].Label)
+				btn.TextSize = s.TextSize
+				btn.Inset = layout.UniformInset(unit.Dp(2))
+				if !a.Editable {
+					btn.Background = s.Muted
+				}
+				return btn.Layout(gtx)
+			})
+		})
+	}
+
+	layout.Flex{Axis: layout.Horizontal}.Layout(gtx, children...)
+	return D{Size: gtx.Constraints.Max}
+}
@@ -0,0 +1,28 @@
+package property
+
+import "testing"
+
+func TestActions(t *testing.T) {
+	for _, tt := range []struct {
+		editable bool
+		want     int
+	}{
+		{editable: true, want: 1},
+		{editable: false, want: 0},
+	} {
+		var calls, others int
+		a := NewActions(
+			Action{Label: "do", Do: func() { calls++ }},
+			Action{Label: "other", Do: func() { others++ }},
+		)
+		a.Editable = tt.editable
+		plist := NewList()
+		plist.Add("actions", a)
+
+		a.clicks[0].Click()
+		layoutFrame(t, plist, nil)
+		if calls != tt.want || others != 0 {
+			t.Errorf("editable=%v: Do called %d times, other %d times, want %d and 0", tt.editable, calls, others, tt.want)
+		}
+	}
+}
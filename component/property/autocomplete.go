@@ -0,0 +1,311 @@
+package property
+
+import (
+	"fmt"
+	"image"
+
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+)
+
+// DefaultSuggestionsMaxHeight is the default maximum height of the popup
+// showing the suggestions of an Autocomplete property.
+const DefaultSuggestionsMaxHeight = unit.Dp(200)
+
+// Autocomplete is a String property showing, while the user types, a popup of
+// suggestions for the entered text. Pressing Down moves the focus to the
+// suggestions, which can then be navigated with the arrow keys and accepted
+// with Enter. Suggestions can also be clicked.
+type Autocomplete struct {
+	*String
+
+	// MustMatch makes the property only accept a value if it's one of the
+	// suggestions for itself.
+	MustMatch bool
+
+	// MaxHeight is the maximum height of the suggestions popup.
+	MaxHeight unit.Dp
+
+	suggest func(prefix string) []string
+	check   Validator
+
+	suggestions []string
+	clicks      []gesture.Click
+	list        layout.List
+	highlighted int
+	lastText    string
+
+	// open reports whether the suggestions popup is shown, navigating
+	// whether the suggestions have the focus and refocus whether the focus is
+	// being given back to the editor.
+	open       bool
+	navigating bool
+	refocus    bool
+}
+
+// NewAutocomplete creates an Autocomplete property holding val, for which
+// suggest returns the suggestions for the text entered by the user.
+func NewAutocomplete(val string, suggest func(prefix string) []string) *Autocomplete {
+	a := &Autocomplete{
+		String:      NewString(val),
+		MaxHeight:   DefaultSuggestionsMaxHeight,
+		suggest:     suggest,
+		highlighted: -1,
+		lastText:    val,
+	}
+	a.String.SetValidator(a.validate)
+	return a
+}
+
+// SetValidator sets an additional validator checking the strings entered by
+// the user. A nil validator accepts any string.
+func (a *Autocomplete) SetValidator(v Validator) {
+	a.check = v
+}
+
+// Set sets the value from its textual representation.
+func (a *Autocomplete) Set(s string) error {
+	if err := a.String.Set(s); err != nil {
+		return err
+	}
+	a.lastText = a.editor.Text()
+	return nil
+}
+
+func (a *Autocomplete) paste(s string) error {
+	err := a.String.paste(s)
+	a.lastText = a.editor.Text()
+	return err
+}
+
+func (a *Autocomplete) validate(s string) error {
+	if a.MustMatch && !contains(a.suggest(s), s) {
+		return fmt.Errorf("%q is not a valid choice", s)
+	}
+	if a.check != nil {
+		return a.check(s)
+	}
+	return nil
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Autocomplete) refresh(prefix string) {
+	a.suggestions = a.suggest(prefix)
+	for len(a.clicks) < len(a.suggestions) {
+		a.clicks = append(a.clicks, gesture.Click{})
+	}
+	a.highlighted = -1
+	a.list.Position = layout.Position{}
+}
+
+func (a *Autocomplete) highlight(i int) {
+	a.highlighted = clamp(0, i, len(a.suggestions)-1)
+	ensureVisible(&a.list, a.highlighted)
+}
+
+// editing reports whether the text is edited, which includes browsing the
+// suggestions while the editor doesn't have the focus.
+func (a *Autocomplete) editing() bool {
+	return a.editor.Focused() || a.navigating || a.refocus
+}
+
+// focusEditor gives the focus back to the editor, after navigating the
+// suggestions.
+func (a *Autocomplete) focusEditor() {
+	if a.navigating {
+		a.navigating = false
+		a.refocus = true
+		a.editor.Focus()
+	}
+}
+
+// accept replaces the edited text with the suggestion at index i.
+func (a *Autocomplete) accept(i int) {
+	s := a.suggestions[i]
+	a.editor.SetText(s)
+	n := a.editor.Len()
+	a.editor.SetCaret(n, n)
+	a.lastText = s
+	a.open = false
+	if a.navigating {
+		a.focusEditor()
+	} else if !a.editor.Focused() {
+		a.commit()
+	}
+}
+
+func (a *Autocomplete) handleKey(gtx C, e key.Event) {
+	if e.State != key.Press {
+		return
+	}
+
+	if !a.navigating {
+		switch e.Name {
+		case key.NameDownArrow:
+			if !a.open {
+				a.refresh(a.editor.Text())
+				a.open = true
+			}
+			if len(a.suggestions) > 0 {
+				a.navigating = true
+				a.highlight(0)
+				key.FocusOp{Tag: a}.Add(gtx.Ops)
+			}
+		case key.NameEscape:
+			a.open = false
+		}
+		return
+	}
+
+	const pageSize = 10
+	switch e.Name {
+	case key.NameUpArrow:
+		if a.highlighted == 0 {
+			a.highlighted = -1
+			a.focusEditor()
+			break
+		}
+		a.highlight(a.highlighted - 1)
+	case key.NameDownArrow:
+		a.highlight(a.highlighted + 1)
+	case key.NamePageUp:
+		a.highlight(a.highlighted - pageSize)
+	case key.NamePageDown:
+		a.highlight(a.highlighted + pageSize)
+	case key.NameReturn, key.NameEnter:
+		if a.highlighted >= 0 {
+			a.accept(a.highlighted)
+		}
+	case key.NameEscape:
+		a.open = false
+		a.focusEditor()
+	case key.NameDeleteBackward:
+		a.editor.Delete(-1)
+		a.focusEditor()
+	}
+}
+
+func (a *Autocomplete) update(gtx C) {
+	for i := range a.suggestions {
+		for _, e := range a.clicks[i].Events(gtx) {
+			if e.Type == gesture.TypeClick {
+				a.accept(i)
+			}
+		}
+		if a.clicks[i].Hovered() {
+			a.highlighted = i
+		}
+	}
+
+	for _, e := range gtx.Events(a) {
+		switch e := e.(type) {
+		case key.FocusEvent:
+			if !e.Focus && a.navigating {
+				// The focus went elsewhere while navigating the
+				// suggestions, it's time to commit.
+				a.navigating = false
+				a.open = false
+				a.commit()
+			}
+			if !e.Focus {
+				a.refocus = false
+			}
+		case key.Event:
+			a.handleKey(gtx, e)
+		case key.EditEvent:
+			// The user types while navigating the suggestions.
+			if a.navigating {
+				a.editor.Insert(e.Text)
+				a.focusEditor()
+			}
+		}
+	}
+
+	// Refresh suggestions when the text changes.
+	if a.editor.Focused() {
+		a.refocus = false
+		if txt := a.editor.Text(); txt != a.lastText {
+			a.lastText = txt
+			a.refresh(txt)
+			a.open = true
+		}
+	} else if !a.navigating && !a.refocus {
+		a.open = false
+	}
+}
+
+func (a *Autocomplete) Layout(th *material.Theme, pgtx, gtx C) D {
+	a.update(gtx)
+
+	// Our key handler encloses the editor one, so that we receive the keys
+	// the editor doesn't handle, such as Down when the caret is at the end.
+	area := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
+	keys := key.Set("↓")
+	switch {
+	case a.navigating:
+		keys = "[↑,↓,⇞,⇟,⏎,⌤,⎋,⌫]"
+	case a.open:
+		keys = "[↓,⎋]"
+	}
+	key.InputOp{Tag: a, Hint: key.HintText, Keys: keys}.Add(gtx.Ops)
+	a.holdCommit = a.navigating || a.refocus
+	dims := a.String.Layout(th, pgtx, gtx)
+	area.Pop()
+
+	if a.open && len(a.suggestions) > 0 {
+		popup := Popup{MaxHeight: a.MaxHeight, MatchWidth: true, Placement: a.placement}
+		popup.Layout(pgtx, gtx, func(gtx C) D {
+			return a.layoutSuggestions(a.listStyle(th), gtx)
+		})
+	}
+	return dims
+}
+
+func (a *Autocomplete) layoutSuggestions(s *ListStyle, gtx C) D {
+	th := s.Theme
+	content := op.Record(gtx.Ops)
+	a.list.Axis = layout.Vertical
+	dims := a.list.Layout(gtx, len(a.suggestions), func(gtx C, i int) D {
+		label := material.Label(th, s.TextSize, a.suggestions[i])
+		label.MaxLines = 1
+		inset := layout.Inset{Top: 4, Right: 8, Bottom: 4, Left: 8}
+
+		macro := op.Record(gtx.Ops)
+		dims := inset.Layout(gtx, label.Layout)
+		call := macro.Stop()
+		dims.Size.X = gtx.Constraints.Max.X
+
+		if i == a.highlighted {
+			paint.FillShape(gtx.Ops, s.Selected, clip.Rect{Max: dims.Size}.Op())
+		}
+		call.Add(gtx.Ops)
+
+		defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+		pointer.CursorPointer.Add(gtx.Ops)
+		a.clicks[i].Add(gtx.Ops)
+		return dims
+	})
+	call := content.Stop()
+
+	gtx.Constraints = layout.Exact(image.Pt(gtx.Constraints.Max.X, dims.Size.Y))
+	return component.Surface(th).Layout(gtx, func(gtx C) D {
+		call.Add(gtx.Ops)
+		return D{Size: gtx.Constraints.Min}
+	})
+}
@@ -0,0 +1,71 @@
+package property
+
+import (
+	"strings"
+	"testing"
+
+	"gioui.org/io/key"
+	"gioui.org/op"
+)
+
+func fruits(prefix string) []string {
+	var matches []string
+	for _, f := range []string{"apple", "apricot", "banana", "blueberry"} {
+		if strings.HasPrefix(f, prefix) {
+			matches = append(matches, f)
+		}
+	}
+	return matches
+}
+
+func TestAutocompleteMustMatch(t *testing.T) {
+	a := NewAutocomplete("apple", fruits)
+	if err := a.val.Set("cherry"); err != nil {
+		t.Fatalf("Set failed without MustMatch: %v", err)
+	}
+
+	a.MustMatch = true
+	if err := a.val.Set("ap"); err == nil {
+		t.Errorf("Set(%q) succeeded with MustMatch", "ap")
+	}
+	if err := a.val.Set("banana"); err != nil {
+		t.Errorf("Set(%q) failed: %v", "banana", err)
+	}
+
+	a.SetValidator(MaxLen(5))
+	if err := a.val.Set("apricot"); err == nil {
+		t.Errorf("Set(%q) succeeded despite the additional validator", "apricot")
+	}
+}
+
+func TestAutocompleteKeys(t *testing.T) {
+	a := NewAutocomplete("", fruits)
+	a.editor.SetText("ap")
+	gtx := C{Ops: new(op.Ops)}
+
+	a.handleKey(gtx, press(key.NameDownArrow, 0))
+	if !a.open || !a.navigating {
+		t.Fatalf("Down should open the suggestions and navigate them")
+	}
+	if len(a.suggestions) != 2 || a.highlighted != 0 {
+		t.Fatalf("suggestions = %v, highlighted = %d", a.suggestions, a.highlighted)
+	}
+	a.handleKey(gtx, press(key.NameDownArrow, 0))
+	a.handleKey(gtx, press(key.NameDownArrow, 0))
+	if a.highlighted != 1 {
+		t.Fatalf("highlighted = %d, want 1", a.highlighted)
+	}
+	a.handleKey(gtx, press(key.NameReturn, 0))
+	if got := a.editor.Text(); got != "apricot" {
+		t.Errorf("editor text = %q, want %q", got, "apricot")
+	}
+	if a.open || a.navigating {
+		t.Errorf("accepting a suggestion should close the popup and focus the editor")
+	}
+
+	a.handleKey(gtx, press(key.NameDownArrow, 0))
+	a.handleKey(gtx, press(key.NameUpArrow, 0))
+	if a.navigating || a.highlighted != -1 {
+		t.Errorf("Up on the first suggestion should give the focus back to the editor")
+	}
+}
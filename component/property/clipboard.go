@@ -0,0 +1,99 @@
+package property
+
+import (
+	"fmt"
+
+	"gioui.org/io/clipboard"
+)
+
+// clipboardState holds the clipboard operations requested by the user, which
+// are performed at next frame.
+type clipboardState struct {
+	// copy is the text to write to the clipboard.
+	copy *string
+
+	// pasteTo is the widget waiting for the text read from the clipboard,
+	// showing the row at pastePath, or if pasteAll is set, the whole list.
+	pasteTo   Widget
+	pastePath string
+	pasteAll  bool
+}
+
+// setPaste requests the text of the clipboard to be pasted in the widget of
+// row r, unless it's read-only.
+func (cs *clipboardState) setPaste(r row) {
+	if !canEdit(r.w) {
+		return
+	}
+	cs.pasteTo = r.w
+	cs.pastePath = r.path
+}
+
+func (cs *clipboardState) setCopy(s string) {
+	cs.copy = &s
+}
+
+func (plist *List) updateClipboard(gtx C) {
+	cs := &plist.clip
+	if cs.copy != nil {
+		clipboard.WriteOp{Text: *cs.copy}.Add(gtx.Ops)
+		cs.copy = nil
+	}
+	for _, e := range gtx.Events(cs) {
+		e, ok := e.(clipboard.Event)
+		if !ok {
+			continue
+		}
+		switch {
+		case cs.pasteAll:
+			if err := plist.unmarshalText([]byte(e.Text), true); err != nil {
+				plist.reportError(err)
+			}
+		case cs.pasteTo != nil:
+			if err := paste(cs.pasteTo, e.Text); err != nil {
+				plist.reportError(fmt.Errorf("%s: %w", cs.pastePath, err))
+			}
+		}
+		cs.pasteTo = nil
+		cs.pasteAll = false
+	}
+	if cs.pasteTo != nil || cs.pasteAll {
+		clipboard.ReadOp{Tag: cs}.Add(gtx.Ops)
+	}
+}
+
+// reportError passes err to the OnError callback, if set.
+func (plist *List) reportError(err error) {
+	if plist.OnError != nil {
+		plist.OnError(err)
+	}
+}
+
+// paster is implemented by property widgets which handle pasted text like
+// typed text, rather than setting their value directly with Set.
+type paster interface {
+	paste(s string) error
+}
+
+// paste sets the value of w from the pasted text s.
+func paste(w Widget, s string) error {
+	switch w := w.(type) {
+	case paster:
+		return w.paste(s)
+	case Stringer:
+		return w.Set(s)
+	}
+	return nil
+}
+
+// editableWidget is implemented by property widgets which can be made
+// read-only.
+type editableWidget interface {
+	editable() bool
+}
+
+// canEdit reports whether the value of w can be changed by the user.
+func canEdit(w Widget) bool {
+	e, ok := w.(editableWidget)
+	return !ok || e.editable()
+}
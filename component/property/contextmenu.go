@@ -0,0 +1,99 @@
+package property
+
+import (
+	"image"
+
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+)
+
+// contextMenu is the menu opened by right-clicking a row of a List.
+type contextMenu struct {
+	area  component.ContextArea
+	state component.MenuState
+
+	// target is the row the menu has been opened for.
+	target  row
+	actions []Action
+	clicks  []widget.Clickable
+}
+
+// contextActions returns the entries of the context menu of row r.
+func (plist *List) contextActions(r row) []Action {
+	cs := &plist.clip
+	copyText := func(s string) func() {
+		return func() { cs.setCopy(s) }
+	}
+
+	var actions []Action
+	if s, ok := r.w.(Stringer); ok {
+		actions = append(actions, Action{Label: "Copy value", Do: func() { cs.setCopy(s.String()) }})
+		if canEdit(r.w) {
+			actions = append(actions, Action{Label: "Paste value", Do: func() { cs.setPaste(r) }})
+		}
+	}
+	if r.prop >= 0 && plist.modified(r.prop) {
+		actions = append(actions, Action{Label: "Reset", Do: func() { plist.reset(r.prop) }})
+	}
+	actions = append(actions, Action{Label: "Copy name", Do: copyText(r.title())})
+	if r.path != r.name {
+		actions = append(actions, Action{Label: "Copy path", Do: copyText(r.path)})
+	}
+	actions = append(actions,
+		Action{Label: "Copy all", Do: func() { cs.setCopy(plist.text()) }},
+		Action{Label: "Paste all", Do: func() { cs.pasteAll = true }},
+	)
+	if plist.ContextActions != nil {
+		actions = append(actions, plist.ContextActions(r.path)...)
+	}
+	return actions
+}
+
+// layoutContextMenu handles the context menu of the rows, gtx minimum
+// constraints covering the rows.
+func (plist *List) layoutContextMenu(th *material.Theme, gtx C) D {
+	m := &plist.menu
+	for i := range m.actions {
+		for m.clicks[i].Clicked() {
+			if m.actions[i].Do != nil {
+				m.actions[i].Do()
+			}
+			m.area.Dismiss()
+		}
+	}
+
+	m.area.Update(gtx)
+	if m.area.Activated() {
+		// The menu opens for the row under the pointer.
+		var target *row
+		first, last := plist.builtRows()
+		for i := first; i < last; i++ {
+			r := plist.row(i)
+			if st, ok := plist.states[r.path]; ok && st.hovered {
+				target = &r
+				break
+			}
+		}
+		if target == nil {
+			m.area.Dismiss()
+		} else {
+			m.target = *target
+			m.actions = plist.contextActions(m.target)
+			m.clicks = make([]widget.Clickable, len(m.actions))
+			m.state.Options = m.state.Options[:0]
+			for i := range m.actions {
+				i := i
+				m.state.Options = append(m.state.Options, func(gtx C) D {
+					item := component.MenuItem(th, &m.clicks[i], m.actions[i].Label)
+					return item.Layout(gtx)
+				})
+			}
+		}
+	}
+
+	return m.area.Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min = image.Point{}
+		return component.Menu(th, &m.state).Layout(gtx)
+	})
+}
@@ -0,0 +1,216 @@
+package property
+
+import (
+	"image"
+	"math"
+
+	"gioui.org/gesture"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+)
+
+// Columns defines the widths of the name and value columns of a List. It's
+// updated when the user moves the divider between the columns, and can be
+// saved and restored to keep the layout across sessions.
+type Columns struct {
+	// Ratio positions the divider relatively to the width of the list, if
+	// NameWidth is 0. 0 is center, -1 completely to the left, 1 completely
+	// to the right.
+	Ratio float32
+
+	// NameWidth, if not 0, is the fixed width of the name column, which
+	// doesn't change when the list is resized.
+	NameWidth unit.Dp
+
+	// MinName and MaxName bound the width of the name column, MinValue and
+	// MaxValue the one of the value column. A zero max means no bound. Value
+	// column bounds take precedence.
+	MinName, MaxName   unit.Dp
+	MinValue, MaxValue unit.Dp
+}
+
+const (
+	// dividerKeys are the keys moving the divider when its handle has the
+	// focus, by dividerStep or, with Shift, by 4 times dividerStep.
+	dividerKeys = "(Shift)-[←,→]"
+	dividerStep = unit.Dp(4)
+)
+
+// divider is the state of the handle resizing the columns.
+type divider struct {
+	click   gesture.Click
+	focused bool
+
+	drag   bool
+	dragID pointer.ID
+	dragX  float32
+}
+
+// columns returns the width of the name column and the horizontal offset and
+// width of the value column, for a row of the given width.
+func (plist *List) columns(gtx C, width int) (lsize, roff, rsize int) {
+	whandle := gtx.Dp(plist.HandleBarWidth)
+	if plist.Columns.NameWidth != 0 {
+		lsize = gtx.Dp(plist.Columns.NameWidth)
+	} else {
+		proportion := (plist.Columns.Ratio + 1) / 2
+		lsize = int(math.Round(float64(proportion*float32(width) - float32(whandle))))
+	}
+	lsize = plist.clampName(gtx, width, lsize)
+	roff = lsize + whandle
+	return lsize, roff, width - roff
+}
+
+// clampName returns lsize, the width of the name column, bounded by the
+// minimum and maximum widths of the columns.
+func (plist *List) clampName(gtx C, width, lsize int) int {
+	cols := &plist.Columns
+	avail := width - gtx.Dp(plist.HandleBarWidth)
+	lsize = max(lsize, gtx.Dp(cols.MinName))
+	if cols.MaxName != 0 {
+		lsize = min(lsize, gtx.Dp(cols.MaxName))
+	}
+	if cols.MaxValue != 0 {
+		lsize = max(lsize, avail-gtx.Dp(cols.MaxValue))
+	}
+	lsize = min(lsize, avail-gtx.Dp(cols.MinValue))
+	return clamp(0, lsize, max(0, avail))
+}
+
+// setNameWidth sets the width of the name column to lsize pixels, in a list of
+// the given width, keeping the current mode: fixed width or ratio.
+func (plist *List) setNameWidth(gtx C, width, lsize int) {
+	lsize = plist.clampName(gtx, width, lsize)
+	if plist.Columns.NameWidth != 0 {
+		plist.Columns.NameWidth = max(1, pxToDp(gtx, lsize))
+		return
+	}
+	if width > 0 {
+		proportion := float32(lsize+gtx.Dp(plist.HandleBarWidth)) / float32(width)
+		plist.Columns.Ratio = proportion*2 - 1
+	}
+}
+
+func pxToDp(gtx C, px int) unit.Dp {
+	pxPerDp := gtx.Metric.PxPerDp
+	if pxPerDp == 0 {
+		pxPerDp = 1
+	}
+	return unit.Dp(float32(px) / pxPerDp)
+}
+
+// fitWidth returns the width of the name column showing the longest name
+// entirely, with its indentation. For a model, only the names of the visible
+// rows are measured.
+func (plist *List) fitWidth(th *material.Theme, gtx C) int {
+	s := plist.style
+	gtx.Constraints = layout.Constraints{Max: image.Pt(1e6, 1e6)}
+	w := 0
+	first, last := plist.builtRows()
+	for i := first; i < last; i++ {
+		r := plist.row(i)
+		if r.label != nil {
+			continue
+		}
+		label := material.Label(th, s.TextSize, r.name)
+		label.MaxLines = 1
+		label.Font = s.NameFont
+		macro := op.Record(gtx.Ops)
+		dims := s.Inset.Layout(gtx, label.Layout)
+		macro.Stop()
+		w = max(w, plist.indent(gtx, r)+dims.Size.X)
+	}
+	return w
+}
+
+// layoutHandle handles the input of the handle resizing the columns, within
+// barrect, for a list as wide as gtx maximum constraints.
+func (plist *List) layoutHandle(gtx C, barrect image.Rectangle) {
+	d := &plist.divider
+	width := gtx.Constraints.Max.X
+	lsize, _, _ := plist.columns(gtx, width)
+
+	for _, e := range d.click.Events(gtx) {
+		switch {
+		case e.Type == gesture.TypePress:
+			key.FocusOp{Tag: &d.focused}.Add(gtx.Ops)
+		case e.Type == gesture.TypeClick && e.NumClicks == 2:
+			lsize = plist.fitWidth(plist.style.Theme, gtx)
+			plist.setNameWidth(gtx, width, lsize)
+		}
+	}
+
+	for _, ev := range gtx.Events(&d.focused) {
+		switch e := ev.(type) {
+		case key.FocusEvent:
+			d.focused = e.Focus
+		case key.Event:
+			if e.State != key.Press {
+				break
+			}
+			step := gtx.Dp(dividerStep)
+			if e.Modifiers.Contain(key.ModShift) {
+				step *= 4
+			}
+			if e.Name == key.NameLeftArrow {
+				step = -step
+			}
+			lsize = plist.clampName(gtx, width, lsize+step)
+			plist.setNameWidth(gtx, width, lsize)
+		}
+	}
+
+	for _, ev := range gtx.Events(d) {
+		e, ok := ev.(pointer.Event)
+		if !ok {
+			continue
+		}
+
+		switch e.Type {
+		case pointer.Press:
+			if d.drag {
+				break
+			}
+
+			d.dragID = e.PointerID
+			d.dragX = e.Position.X
+
+		case pointer.Drag:
+			if d.dragID != e.PointerID {
+				break
+			}
+
+			// Clamp drag position so that the 'handle' remains always visible.
+			whandle := gtx.Dp(plist.HandleBarWidth)
+			posx := float32(clamp(whandle, int(e.Position.X), width-whandle))
+			delta := int(posx - d.dragX)
+			lsize = plist.clampName(gtx, width, lsize+delta)
+			plist.setNameWidth(gtx, width, lsize)
+			d.dragX += float32(delta)
+
+		case pointer.Release, pointer.Cancel:
+			d.drag = false
+		}
+	}
+
+	if d.focused {
+		paint.FillShape(gtx.Ops, plist.style.Focused, clip.Rect(barrect).Op())
+	}
+
+	// Register for receving input in the handlebar rect.
+	defer clip.Rect(barrect).Push(gtx.Ops).Pop()
+	pointer.CursorColResize.Add(gtx.Ops)
+	pointer.InputOp{
+		Tag:   d,
+		Types: pointer.Press | pointer.Drag | pointer.Release,
+		Grab:  d.drag,
+	}.Add(gtx.Ops)
+	d.click.Add(gtx.Ops)
+	key.InputOp{Tag: &d.focused, Keys: dividerKeys}.Add(gtx.Ops)
+}
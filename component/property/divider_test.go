@@ -0,0 +1,62 @@
+package property
+
+import (
+	"testing"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+)
+
+func TestColumns(t *testing.T) {
+	gtx := layout.Context{}
+	tests := []struct {
+		name  string
+		cols  Columns
+		width int
+		lsize int
+	}{
+		{"center", Columns{}, 200, 97},
+		{"ratio", Columns{Ratio: -0.5}, 200, 47},
+		{"fixed", Columns{NameWidth: 80}, 200, 80},
+		{"fixed wide", Columns{NameWidth: 80}, 400, 80},
+		{"min name", Columns{Ratio: -1, MinName: 30}, 200, 30},
+		{"max name", Columns{Ratio: 1, MaxName: 120}, 200, 120},
+		{"min value", Columns{NameWidth: 180, MinValue: 50}, 200, 147},
+		{"max value", Columns{NameWidth: 20, MaxValue: 100}, 200, 97},
+		{"too narrow", Columns{NameWidth: 80, MinValue: 50}, 40, 0},
+	}
+	for _, tt := range tests {
+		plist := NewList()
+		plist.Columns = tt.cols
+		lsize, roff, rsize := plist.columns(gtx, tt.width)
+		if lsize != tt.lsize {
+			t.Errorf("%s: name width = %d, want %d", tt.name, lsize, tt.lsize)
+		}
+		if roff != lsize+3 || roff+rsize != tt.width {
+			t.Errorf("%s: value column at %d, width %d, want %d and %d", tt.name, roff, rsize, lsize+3, tt.width-lsize-3)
+		}
+	}
+}
+
+func TestSetNameWidth(t *testing.T) {
+	gtx := layout.Context{}
+
+	plist := NewList()
+	plist.setNameWidth(gtx, 200, 57)
+	if lsize, _, _ := plist.columns(gtx, 200); lsize != 57 {
+		t.Errorf("ratio: name width = %d, want 57", lsize)
+	}
+	if lsize, _, _ := plist.columns(gtx, 400); lsize != 117 {
+		t.Errorf("ratio: name width = %d after resize, want 117", lsize)
+	}
+
+	plist.Columns = Columns{NameWidth: 100, MaxName: 150}
+	plist.setNameWidth(gtx, 200, 60)
+	if got := plist.Columns.NameWidth; got != 60 {
+		t.Errorf("fixed: NameWidth = %v, want 60", got)
+	}
+	plist.setNameWidth(gtx, 200, 180)
+	if got := plist.Columns.NameWidth; got != unit.Dp(150) {
+		t.Errorf("fixed: NameWidth = %v, want 150", got)
+	}
+}
@@ -1,7 +1,12 @@
 package property
 
 import (
+	"fmt"
 	"image"
+	"image/color"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"gioui.org/f32"
 	"gioui.org/gesture"
@@ -12,109 +17,700 @@ import (
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
 	"gioui.org/text"
+	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
 	"gioui.org/x/component"
 )
 
-var darkGrey = rgb(0xa9a9a9)
+const (
+	// DefaultFilterThreshold is the default number of items above which a
+	// DropDown menu shows a filter box.
+	DefaultFilterThreshold = 20
 
+	// DefaultMenuMaxHeight is the default maximum height of a DropDown menu.
+	DefaultMenuMaxHeight = unit.Dp(300)
+
+	// typeAheadDelay is the delay after which the text typed to jump to an
+	// item is forgotten.
+	typeAheadDelay = time.Second
+
+	// NoSelection is the value of DropDown.Selected when no item is selected.
+	NoSelection = -1
+)
+
+// Keys handled by a DropDown, depending on whether its menu is opened.
+const (
+	closedKeys = key.Set("[↑,↓,⏎,⌤,Space]|Alt-↓")
+	openedKeys = key.Set("[↑,↓,⇞,⇟,⇱,⇲,⏎,⌤,⎋,⌫]|Alt-↑")
+)
+
+// ItemKind is the kind of an entry of a DropDown menu.
+type ItemKind uint8
+
+const (
+	// ItemOption is a selectable item.
+	ItemOption ItemKind = iota
+	// ItemSeparator is a horizontal line separating groups of items.
+	ItemSeparator
+	// ItemHeading is the non-selectable title of a group of items.
+	ItemHeading
+)
+
+// An Item is an entry of a DropDown menu.
+type Item struct {
+	Kind ItemKind
+
+	// Label is the item text, or the heading title.
+	Label string
+
+	// Hint is a secondary text shown on the right of the label in the menu.
+	Hint string
+
+	// Icon, if not nil, is shown on the left of the label.
+	Icon *widget.Icon
+
+	// Disabled items are shown but can't be selected.
+	Disabled bool
+}
+
+// Separator returns a separator item.
+func Separator() Item {
+	return Item{Kind: ItemSeparator}
+}
+
+// Heading returns a group heading item.
+func Heading(title string) Item {
+	return Item{Kind: ItemHeading, Label: title}
+}
+
+// NewDropDown creates a DropDown with an option item per string in items.
 func NewDropDown(items []string) *DropDown {
-	return &DropDown{items: items}
+	ditems := make([]Item, len(items))
+	for i, label := range items {
+		ditems[i] = Item{Label: label}
+	}
+	return NewDropDownItems(ditems)
 }
 
+// NewDropDownItems creates a DropDown showing items, which may include
+// separators and headings. The first selectable item is selected.
+func NewDropDownItems(items []Item) *DropDown {
+	a := &DropDown{
+		items:           items,
+		FilterThreshold: DefaultFilterThreshold,
+		MenuMaxHeight:   DefaultMenuMaxHeight,
+		Selected:        NoSelection,
+	}
+	a.selectNext(1)
+	return a
+}
+
+// NewDropDownProvider creates a DropDown whose items are provided by
+// provider, each time the menu is opened. Initially no item is selected.
+func NewDropDownProvider(provider func() []Item) *DropDown {
+	a := NewDropDownItems(nil)
+	a.Provider = provider
+	return a
+}
+
+// Items returns the items shown by the dropdown.
+func (a *DropDown) Items() []Item {
+	return a.items
+}
+
+// SetItems replaces the items shown by the dropdown. The selection is
+// preserved if an option with the same label as the selected item exists in
+// items, otherwise nothing is selected.
+func (a *DropDown) SetItems(items []Item) {
+	if a.multi != nil {
+		a.multi.remap(a.items, items)
+	}
+	sel, ok := a.SelectedItem()
+	a.items = items
+	a.growClickables()
+	a.Selected = NoSelection
+	if ok {
+		for i := range items {
+			if items[i].Kind == ItemOption && items[i].Label == sel.Label {
+				a.Selected = i
+				break
+			}
+		}
+	}
+
+	if a.open {
+		a.refilter()
+		a.move(0, 1)
+	}
+}
+
+// SelectedItem returns the selected item. ok is false if there's no selection,
+// in which case the returned item is the zero Item.
+func (a *DropDown) SelectedItem() (item Item, ok bool) {
+	if a.Selected < 0 || a.Selected >= len(a.items) || a.items[a.Selected].Kind != ItemOption {
+		return Item{}, false
+	}
+	return a.items[a.Selected], true
+}
+
+// DropDown is a property widget allowing to select one item among a list. It
+// can be used with the mouse or the keyboard: when focused, Up and Down change
+// the selected item while Enter, Space or Alt+Down open the menu. In the
+// opened menu, the arrow keys move the highlighted item and typing jumps to the
+// first item starting with the typed text.
 type DropDown struct {
+	// Selected is the index of the selected item, or NoSelection.
 	Selected int
 
-	items      []string
-	area       component.ContextArea
-	menu       component.MenuState
-	clickables []*widget.Clickable
+	// Placeholder is the text shown when no item is selected.
+	Placeholder string
+
+	// Provider, if set, is called each time the menu is opened to refresh the
+	// list of items, as with SetItems.
+	Provider func() []Item
+
+	// FilterThreshold is the number of items above which the menu shows a
+	// filter box at its top. Then, typing filters out the items not
+	// containing the typed text. If 0, no filter box is ever shown.
+	FilterThreshold int
+
+	// MenuMaxHeight is the maximum height of the menu, past which it scrolls.
+	MenuMaxHeight unit.Dp
+
+	items      []Item
+	clickables []widget.Clickable
+	listed
+
+	open bool
+	menu layout.List
+
+	// filtered holds the indices of the items shown in the menu and
+	// highlighted is the position of the highlighted item in filtered.
+	filtered    []int
+	highlighted int
+
+	// typed is either the filter or the type-ahead text, depending on
+	// whether the filter box is shown.
+	typed   string
+	typedAt time.Time
 
 	focused bool
 	click   gesture.Click
+
+	// multi is only set for a MultiDropDown.
+	multi *multiSelection
 }
 
-func (a *DropDown) Layout(th *material.Theme, pgtx, gtx C) D {
-	// Handle menu selection.
-	a.menu.Options = a.menu.Options[:0]
-	for len(a.clickables) <= len(a.items) {
-		a.clickables = append(a.clickables, &widget.Clickable{})
+func (a *DropDown) editing() bool {
+	return a.open
+}
+
+// String returns the label of the selected item, or an empty string if there's
+// no selection.
+func (a *DropDown) String() string {
+	item, _ := a.SelectedItem()
+	return item.Label
+}
+
+// Set selects the item having the given label. An empty label clears the
+// selection.
+func (a *DropDown) Set(label string) error {
+	if label == "" {
+		a.Selected = NoSelection
+		return nil
+	}
+	if a.Provider != nil {
+		a.SetItems(a.Provider())
 	}
+	i := a.indexOf(label)
+	if i < 0 {
+		return fmt.Errorf("no item %q", label)
+	}
+	a.Selected = i
+	return nil
+}
+
+// indexOf returns the index of the selectable item having the given label, or
+// -1.
+func (a *DropDown) indexOf(label string) int {
 	for i := range a.items {
-		click := a.clickables[i]
-		if click.Clicked() {
+		if a.selectable(i) && a.items[i].Label == label {
+			return i
+		}
+	}
+	return -1
+}
+
+func (a *DropDown) showFilter() bool {
+	return a.FilterThreshold > 0 && len(a.items) > a.FilterThreshold
+}
+
+// selectable reports whether the item at index i can be selected.
+func (a *DropDown) selectable(i int) bool {
+	return a.items[i].Kind == ItemOption && !a.items[i].Disabled
+}
+
+// selectNext selects the next selectable item in direction dir (1 or -1), if
+// there's one.
+func (a *DropDown) selectNext(dir int) {
+	start := clamp(-1, a.Selected, len(a.items)) + dir
+	for i := start; i >= 0 && i < len(a.items); i += dir {
+		if a.selectable(i) {
 			a.Selected = i
+			return
+		}
+	}
+}
+
+func (a *DropDown) openMenu() {
+	if a.Provider != nil {
+		a.SetItems(a.Provider())
+	}
+	a.open = true
+	a.typed = ""
+	a.refilter()
+	a.move(0, 1)
+	for i, idx := range a.filtered {
+		if idx == a.Selected {
+			a.highlight(i)
+			break
+		}
+	}
+}
+
+func (a *DropDown) closeMenu() {
+	a.open = false
+	a.typed = ""
+}
+
+func (a *DropDown) selectHighlighted() {
+	if a.highlighted < 0 || a.highlighted >= len(a.filtered) || !a.selectable(a.filtered[a.highlighted]) {
+		a.closeMenu()
+		return
+	}
+	a.selectItem(a.filtered[a.highlighted])
+}
+
+// selectItem selects the item at index i and closes the menu or, for
+// multi-selection dropdowns, toggles the item and keeps the menu opened.
+func (a *DropDown) selectItem(i int) {
+	if a.multi != nil {
+		a.multi.toggle(i)
+		return
+	}
+	a.Selected = i
+	a.closeMenu()
+}
+
+// refilter updates the list of items shown in the menu.
+func (a *DropDown) refilter() {
+	a.filtered = a.filtered[:0]
+	filter := ""
+	if a.showFilter() {
+		filter = strings.ToLower(a.typed)
+	}
+	for i, item := range a.items {
+		if filter == "" {
+			a.filtered = append(a.filtered, i)
+			continue
+		}
+		// Only keep matching options when filtering.
+		if item.Kind == ItemOption && strings.Contains(strings.ToLower(item.Label), filter) {
+			a.filtered = append(a.filtered, i)
+		}
+	}
+}
+
+// step returns the position in the menu of the first selectable item, starting
+// at position from and moving in direction dir (1 or -1), or -1 if there's
+// none.
+func (a *DropDown) step(from, dir int) int {
+	for i := from; i >= 0 && i < len(a.filtered); i += dir {
+		if a.selectable(a.filtered[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// move highlights the selectable item the closest to position i in the menu,
+// looking first in direction dir (1 or -1).
+func (a *DropDown) move(i, dir int) {
+	i = clamp(0, i, len(a.filtered)-1)
+	j := a.step(i, dir)
+	if j == -1 {
+		j = a.step(i, -dir)
+	}
+	if j != -1 {
+		a.highlight(j)
+	}
+}
+
+// highlight highlights the item at position i in the menu, and scrolls the
+// menu so that it's visible.
+func (a *DropDown) highlight(i int) {
+	a.highlighted = clamp(0, i, len(a.filtered)-1)
+	ensureVisible(&a.menu, a.highlighted)
+}
+
+// ensureVisible scrolls l, if necessary, so that the child at index i is
+// visible.
+func ensureVisible(l *layout.List, i int) {
+	pos := &l.Position
+	switch {
+	case i < pos.First:
+		pos.First, pos.Offset = i, 0
+	case pos.Count > 1 && i >= pos.First+pos.Count-1:
+		pos.First, pos.Offset = i-pos.Count+2, 0
+	}
+}
+
+// typeAhead highlights the first item starting with the typed text.
+func (a *DropDown) typeAhead() {
+	prefix := strings.ToLower(a.typed)
+	for i, idx := range a.filtered {
+		if a.selectable(idx) && strings.HasPrefix(strings.ToLower(a.items[idx].Label), prefix) {
+			a.highlight(i)
+			return
+		}
+	}
+}
+
+func (a *DropDown) handleKey(e key.Event) {
+	if e.State != key.Press {
+		return
+	}
+
+	if !a.open {
+		switch e.Name {
+		case key.NameUpArrow:
+			if a.multi == nil {
+				a.selectNext(-1)
+			}
+		case key.NameDownArrow:
+			if e.Modifiers.Contain(key.ModAlt) {
+				a.openMenu()
+				break
+			}
+			if a.multi == nil {
+				a.selectNext(1)
+			}
+		case key.NameReturn, key.NameEnter, key.NameSpace:
+			a.openMenu()
+		}
+		return
+	}
+
+	const pageSize = 10
+	switch e.Name {
+	case key.NameUpArrow:
+		if e.Modifiers.Contain(key.ModAlt) {
+			a.closeMenu()
+			break
+		}
+		a.move(a.highlighted-1, -1)
+	case key.NameDownArrow:
+		a.move(a.highlighted+1, 1)
+	case key.NamePageUp:
+		a.move(a.highlighted-pageSize, -1)
+	case key.NamePageDown:
+		a.move(a.highlighted+pageSize, 1)
+	case key.NameHome:
+		a.move(0, 1)
+	case key.NameEnd:
+		a.move(len(a.filtered)-1, -1)
+	case key.NameReturn, key.NameEnter:
+		a.selectHighlighted()
+	case key.NameEscape:
+		a.closeMenu()
+	case key.NameDeleteBackward:
+		_, n := utf8.DecodeLastRuneInString(a.typed)
+		a.typed = a.typed[:len(a.typed)-n]
+		if a.showFilter() {
+			a.refilter()
+			a.move(0, 1)
+		}
+	}
+}
+
+func (a *DropDown) handleText(gtx C, s string) {
+	if a.showFilter() {
+		a.typed += s
+		a.refilter()
+		a.move(0, 1)
+		return
+	}
+
+	if gtx.Now.Sub(a.typedAt) > typeAheadDelay {
+		a.typed = ""
+	}
+	a.typed += s
+	a.typedAt = gtx.Now
+	a.typeAhead()
+}
+
+// growClickables makes sure there's a clickable for each item, items may
+// have been replaced while opening the menu.
+func (a *DropDown) growClickables() {
+	for len(a.clickables) < len(a.items) {
+		a.clickables = append(a.clickables, widget.Clickable{})
+	}
+}
+
+func (a *DropDown) update(gtx C) {
+	a.growClickables()
+
+	// Handle menu selection.
+	for i := range a.items {
+		for a.clickables[i].Clicked() {
+			if a.selectable(i) {
+				a.selectItem(i)
+			}
 		}
-		a.menu.Options = append(a.menu.Options, component.MenuItem(th, click, a.items[i]).Layout)
 	}
-	a.area.Activation = pointer.ButtonPrimary
-	a.area.AbsolutePosition = true
 
 	// Handle focus "manually". When the dropdown is closed we draw a label,
-	// which can't receive focus. By registering a key.InputOp we can then receive
-	// focus events (and draw the focus border). We also want to grab the focus when
-	// the dropdown is opened: we do this with a.click.
+	// which can't receive focus. By registering a key.InputOp we can then
+	// receive focus and key events (and draw the focus border). We also want
+	// to grab the focus when the dropdown is clicked: we do this with a.click.
+	wasOpen := a.open
 	for _, e := range gtx.Events(a) {
 		switch e := e.(type) {
 		case key.FocusEvent:
 			a.focused = e.Focus
+			if !a.focused {
+				a.closeMenu()
+			}
+		case key.Event:
+			a.handleKey(e)
+		case key.EditEvent:
+			// Ignore text typed while the menu was closed, such as the
+			// space used to open it.
+			if wasOpen && a.open {
+				a.handleText(gtx, e.Text)
+			}
+		}
+	}
+	for _, e := range a.click.Events(gtx) {
+		if e.Type == gesture.TypeClick {
+			a.openMenu()
 		}
 	}
-	a.click.Events(gtx)
 	if a.click.Pressed() {
 		// Request focus
 		key.FocusOp{Tag: a}.Add(gtx.Ops)
 	}
 
+	// Dismiss the menu when the user presses outside of it.
+	for _, e := range gtx.Events(&a.open) {
+		if e, ok := e.(pointer.Event); ok && e.Type == pointer.Press {
+			a.closeMenu()
+		}
+	}
+}
+
+func (a *DropDown) Layout(th *material.Theme, pgtx, gtx C) D {
+	a.update(gtx)
+
 	// Clip events to the widget area only.
 	clipOp := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
-	key.InputOp{Tag: a, Hint: key.HintAny}.Add(gtx.Ops)
+	keys := closedKeys
+	if a.open {
+		keys = openedKeys
+	}
+	key.InputOp{Tag: a, Hint: key.HintAny, Keys: keys}.Add(gtx.Ops)
 	a.click.Add(gtx.Ops)
 	clipOp.Pop()
 
-	wgtx := gtx
-	return layout.Stack{}.Layout(pgtx,
-		layout.Stacked(func(gtx C) D {
-			gtx.Constraints = layout.Exact(wgtx.Constraints.Max)
-			defer clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops).Pop()
-
-			inset := layout.Inset{Top: 1, Right: 4, Bottom: 1, Left: 4}
-			label := material.Label(th, th.TextSize, a.items[a.Selected])
-			label.MaxLines = 1
-			label.TextSize = th.TextSize
-			label.Alignment = text.Start
-			label.Color = th.Fg
-
-			// Draw a triangle to discriminate a drop down widgets from text props.
-			//      w
-			//  _________  _
-			//  \       /  |
-			//   \  o  /   | h
-			//    \   /    |
-			//     \ /     |
-			// (o is the offset from which we begin drawing).
-			const w, h = 13, 7
-			off := image.Pt(gtx.Constraints.Max.X-w, gtx.Constraints.Max.Y/2-h)
-			stack := op.Offset(off).Push(gtx.Ops)
-			anchor := clip.Path{}
-			anchor.Begin(gtx.Ops)
-			anchor.Move(f32.Pt(-w/2, +h/2))
-			anchor.Line(f32.Pt(w, 0))
-			anchor.Line(f32.Pt(-w/2, h))
-			anchor.Line(f32.Pt(-w/2, -h))
-			anchor.Close()
-			anchorArea := clip.Outline{Path: anchor.End()}.Op()
-			paint.FillShape(gtx.Ops, darkGrey, anchorArea)
-			stack.Pop()
-
-			return FocusBorder(th, a.focused).Layout(gtx, func(gtx C) D {
-				return inset.Layout(gtx, label.Layout)
+	if a.open {
+		a.layoutMenu(th, pgtx, gtx)
+	}
+
+	gtx.Constraints = layout.Exact(gtx.Constraints.Max)
+	defer clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops).Pop()
+
+	st := a.listStyle(th)
+	item, ok := a.SelectedItem()
+	label := material.Label(th, st.TextSize, item.Label)
+	label.MaxLines = 1
+	label.Alignment = text.Start
+	label.Color = th.Fg
+	if !ok {
+		label.Text = a.Placeholder
+		label.Color = st.Muted
+	}
+
+	// Draw a triangle to discriminate a drop down widgets from text props.
+	//      w
+	//  _________  _
+	//  \       /  |
+	//   \  o  /   | h
+	//    \   /    |
+	//     \ /     |
+	// (o is the offset from which we begin drawing).
+	const w, h = 13, 7
+	off := image.Pt(gtx.Constraints.Max.X-w, gtx.Constraints.Max.Y/2-h)
+	stack := op.Offset(off).Push(gtx.Ops)
+	anchor := clip.Path{}
+	anchor.Begin(gtx.Ops)
+	anchor.Move(f32.Pt(-w/2, +h/2))
+	anchor.Line(f32.Pt(w, 0))
+	anchor.Line(f32.Pt(-w/2, h))
+	anchor.Line(f32.Pt(-w/2, -h))
+	anchor.Close()
+	anchorArea := clip.Outline{Path: anchor.End()}.Op()
+	paint.FillShape(gtx.Ops, st.Muted, anchorArea)
+	stack.Pop()
+
+	return st.focusBorder(a.focused).Layout(gtx, func(gtx C) D {
+		return st.Inset.Layout(gtx, func(gtx C) D {
+			if a.multi != nil {
+				// Leave room for the triangle.
+				gtx.Constraints.Max.X -= w
+				return a.multi.layoutSummary(st, gtx, a)
+			}
+			return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+				layout.Rigid(func(gtx C) D {
+					if item.Icon == nil {
+						return D{}
+					}
+					sz := gtx.Constraints.Max.Y
+					gtx.Constraints = layout.Exact(image.Pt(sz, sz))
+					dims := item.Icon.Layout(gtx, th.Fg)
+					dims.Size.X += gtx.Dp(4)
+					return dims
+				}),
+				layout.Rigid(label.Layout),
+			)
+		})
+	})
+}
+
+// layoutMenu lays out the opened menu as a popup of the dropdown, on top of
+// everything else.
+func (a *DropDown) layoutMenu(th *material.Theme, pgtx, gtx C) {
+	// Lay out a transparent scrim to detect presses outside of the menu.
+	macro := op.Record(gtx.Ops)
+	scrim := clip.Rect{Min: image.Pt(-1e6, -1e6), Max: image.Pt(1e6, 1e6)}.Push(gtx.Ops)
+	pointer.InputOp{Tag: &a.open, Types: pointer.Press}.Add(gtx.Ops)
+	scrim.Pop()
+	op.Defer(gtx.Ops, macro.Stop())
+
+	popup := Popup{MaxHeight: a.MenuMaxHeight, MatchWidth: true, Placement: a.placement}
+	popup.Layout(pgtx, gtx, func(gtx C) D {
+		content := op.Record(gtx.Ops)
+		dims := a.layoutMenuContent(a.listStyle(th), gtx)
+		call := content.Stop()
+
+		gtx.Constraints = layout.Exact(dims.Size)
+		return component.Surface(th).Layout(gtx, func(gtx C) D {
+			// Prevent presses in the menu from reaching the scrim.
+			defer clip.Rect{Max: dims.Size}.Push(gtx.Ops).Pop()
+			pointer.InputOp{Tag: &a.menu, Types: pointer.Press}.Add(gtx.Ops)
+			call.Add(gtx.Ops)
+			return dims
+		})
+	})
+}
+
+func (a *DropDown) layoutMenuContent(st *ListStyle, gtx C) D {
+	th := st.Theme
+	a.menu.Axis = layout.Vertical
+	return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			if !a.showFilter() {
+				return D{}
+			}
+			return a.layoutFilter(st, gtx)
+		}),
+		layout.Rigid(func(gtx C) D {
+			return a.menu.Layout(gtx, len(a.filtered), func(gtx C, i int) D {
+				gtx.Constraints.Min.X = gtx.Constraints.Max.X
+				return a.layoutItem(th, gtx, i)
 			})
 		}),
+	)
+}
+
+// layoutItem lays out the menu entry at position i.
+func (a *DropDown) layoutItem(th *material.Theme, gtx C, i int) D {
+	idx := a.filtered[i]
+	it := a.items[idx]
+
+	switch it.Kind {
+	case ItemSeparator:
+		div := component.Divider(th)
+		div.Inset = layout.Inset{Top: 4, Bottom: 4}
+		return div.Layout(gtx)
+	case ItemHeading:
+		label := component.DividerSubheadingText(th, it.Label)
+		label.TextSize = th.TextSize * 0.9
+		label.MaxLines = 1
+		inset := layout.Inset{Top: 6, Right: 8, Bottom: 2, Left: 8}
+		return inset.Layout(gtx, label.Layout)
+	}
+
+	item := component.MenuItem(th, &a.clickables[idx], it.Label)
+	item.Label.TextSize = th.TextSize
+	item.Label.MaxLines = 1
+	item.LabelInset = layout.Inset{Top: 4, Right: 8, Bottom: 4, Left: 8}
+	if it.Icon != nil {
+		item.Icon = it.Icon
+		item.IconSize = unit.Dp(16)
+		item.IconInset = layout.Inset{Left: 8}
+	}
+	if it.Hint != "" {
+		item.Hint = component.MenuHintText(th, it.Hint)
+		item.Hint.TextSize = th.TextSize
+		item.HintInset = layout.Inset{Right: 8}
+	}
+	if it.Disabled {
+		gtx = gtx.Disabled()
+		item.Label.Color = component.WithAlpha(item.Label.Color, 0x60)
+		item.IconColor = component.WithAlpha(item.IconColor, 0x60)
+	}
+
+	// Hovering and keyboard navigation share the same highlight.
+	hover := item.HoverColor
+	item.HoverColor = color.NRGBA{}
+	if a.clickables[idx].Hovered() && a.selectable(idx) {
+		a.highlighted = i
+	}
+	w := item.Layout
+	if a.multi != nil {
+		w = func(gtx C) D {
+			return a.multi.layoutCheck(gtx, idx, item)
+		}
+	}
+	if i != a.highlighted {
+		return w(gtx)
+	}
+	return layout.Stack{}.Layout(gtx,
 		layout.Expanded(func(gtx C) D {
-			gtx.Constraints = layout.Exact(gtx.Constraints.Max)
-			return a.area.Layout(gtx, component.Menu(th, &a.menu).Layout)
+			paint.FillShape(gtx.Ops, hover, clip.Rect{Max: gtx.Constraints.Min}.Op())
+			return D{Size: gtx.Constraints.Min}
 		}),
+		layout.Stacked(w),
 	)
 }
+
+// layoutFilter lays out the box showing the text typed to filter items.
+func (a *DropDown) layoutFilter(st *ListStyle, gtx C) D {
+	label := material.Label(st.Theme, st.TextSize, a.typed+"|")
+	if a.typed == "" {
+		label.Text = "type to filter…"
+		label.Color = st.Muted
+	}
+	label.MaxLines = 1
+	inset := layout.Inset{Top: 4, Right: 8, Bottom: 4, Left: 8}
+	dims := inset.Layout(gtx, label.Layout)
+	dims.Size.X = gtx.Constraints.Max.X
+
+	// Draw bottom border.
+	paint.FillShape(gtx.Ops, st.Muted, clip.Rect{
+		Min: image.Pt(0, dims.Size.Y-1),
+		Max: dims.Size,
+	}.Op())
+	return dims
+}
@@ -0,0 +1,216 @@
+package property
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"gioui.org/font/gofont"
+	"gioui.org/io/key"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget/material"
+	"golang.org/x/exp/slices"
+)
+
+func press(name string, mods key.Modifiers) key.Event {
+	return key.Event{Name: name, Modifiers: mods, State: key.Press}
+}
+
+func TestDropDownKeys(t *testing.T) {
+	dd := NewDropDown([]string{"alpha", "beta", "gamma", "delta"})
+
+	dd.handleKey(press(key.NameDownArrow, 0))
+	dd.handleKey(press(key.NameDownArrow, 0))
+	if dd.Selected != 2 {
+		t.Fatalf("Selected = %d, want 2", dd.Selected)
+	}
+	dd.handleKey(press(key.NameUpArrow, 0))
+	if dd.Selected != 1 {
+		t.Fatalf("Selected = %d, want 1", dd.Selected)
+	}
+
+	dd.handleKey(press(key.NameDownArrow, key.ModAlt))
+	if !dd.open {
+		t.Fatalf("menu should be opened by Alt+Down")
+	}
+	if dd.highlighted != 1 {
+		t.Fatalf("highlighted = %d, want 1", dd.highlighted)
+	}
+	dd.handleKey(press(key.NameEnd, 0))
+	dd.handleKey(press(key.NameEscape, 0))
+	if dd.open || dd.Selected != 1 {
+		t.Fatalf("Escape should close the menu without changing the selection")
+	}
+
+	dd.handleKey(press(key.NameReturn, 0))
+	dd.handleKey(press(key.NameDownArrow, 0))
+	dd.handleKey(press(key.NameReturn, 0))
+	if dd.open || dd.Selected != 2 {
+		t.Fatalf("open=%t Selected=%d, want closed menu and 2", dd.open, dd.Selected)
+	}
+}
+
+func TestDropDownTypeAhead(t *testing.T) {
+	dd := NewDropDown([]string{"alpha", "beta", "delta", "default"})
+	dd.openMenu()
+
+	var gtx C
+	gtx.Now = time.Now()
+	dd.handleText(gtx, "d")
+	dd.handleText(gtx, "e")
+	dd.handleText(gtx, "f")
+	if dd.highlighted != 3 {
+		t.Errorf("highlighted = %d, want 3", dd.highlighted)
+	}
+
+	// Typed text is forgotten after a delay.
+	gtx.Now = gtx.Now.Add(2 * typeAheadDelay)
+	dd.handleText(gtx, "b")
+	if dd.highlighted != 1 {
+		t.Errorf("highlighted = %d, want 1", dd.highlighted)
+	}
+}
+
+func TestDropDownFilter(t *testing.T) {
+	dd := NewDropDown([]string{"alpha", "beta", "gamma", "delta"})
+	dd.FilterThreshold = 2
+	dd.openMenu()
+
+	var gtx C
+	dd.handleText(gtx, "ta")
+	if want := []int{1, 3}; !slices.Equal(dd.filtered, want) {
+		t.Fatalf("filtered = %v, want %v", dd.filtered, want)
+	}
+	dd.handleKey(press(key.NameDownArrow, 0))
+	dd.handleKey(press(key.NameReturn, 0))
+	if dd.Selected != 3 {
+		t.Errorf("Selected = %d, want 3", dd.Selected)
+	}
+
+	dd.openMenu()
+	if len(dd.filtered) != 4 {
+		t.Errorf("reopening the menu should reset the filter")
+	}
+}
+
+func TestDropDownItems(t *testing.T) {
+	dd := NewDropDownItems([]Item{
+		Heading("first"),
+		{Label: "a"},
+		{Label: "b", Disabled: true},
+		Separator(),
+		Heading("second"),
+		{Label: "c"},
+	})
+	if dd.Selected != 1 {
+		t.Fatalf("Selected = %d, want 1, the first selectable item", dd.Selected)
+	}
+
+	dd.handleKey(press(key.NameDownArrow, 0))
+	if dd.Selected != 5 {
+		t.Fatalf("Selected = %d, want 5", dd.Selected)
+	}
+	dd.handleKey(press(key.NameUpArrow, 0))
+	if dd.Selected != 1 {
+		t.Fatalf("Selected = %d, want 1", dd.Selected)
+	}
+	dd.handleKey(press(key.NameUpArrow, 0))
+	if dd.Selected != 1 {
+		t.Fatalf("Selected = %d, want 1, there's no selectable item above", dd.Selected)
+	}
+
+	dd.openMenu()
+	dd.handleKey(press(key.NameEnd, 0))
+	dd.handleKey(press(key.NameUpArrow, 0))
+	if dd.highlighted != 1 {
+		t.Fatalf("highlighted = %d, want 1", dd.highlighted)
+	}
+	dd.handleKey(press(key.NameHome, 0))
+	if dd.highlighted != 1 {
+		t.Fatalf("highlighted = %d, want 1", dd.highlighted)
+	}
+}
+
+func TestDropDownSetItems(t *testing.T) {
+	dd := NewDropDown([]string{"a", "b", "c"})
+	dd.Selected = 1
+
+	dd.SetItems([]Item{{Label: "c"}, {Label: "b"}})
+	if dd.Selected != 1 {
+		t.Errorf("Selected = %d, want 1, selection should be preserved by label", dd.Selected)
+	}
+	dd.SetItems([]Item{{Label: "x"}})
+	if dd.Selected != NoSelection {
+		t.Errorf("Selected = %d, want NoSelection", dd.Selected)
+	}
+	if _, ok := dd.SelectedItem(); ok {
+		t.Errorf("SelectedItem() ok = true, want false")
+	}
+
+	dd.Selected = 42
+	if _, ok := dd.SelectedItem(); ok {
+		t.Errorf("SelectedItem() ok = true with out of range selection")
+	}
+	dd.handleKey(press(key.NameUpArrow, 0))
+	if dd.Selected != 0 {
+		t.Errorf("Selected = %d, want 0", dd.Selected)
+	}
+
+	empty := NewDropDown(nil)
+	empty.handleKey(press(key.NameDownArrow, 0))
+	empty.handleKey(press(key.NameReturn, 0))
+	empty.handleKey(press(key.NameReturn, 0))
+	if empty.Selected != NoSelection {
+		t.Errorf("Selected = %d, want NoSelection", empty.Selected)
+	}
+}
+
+func TestDropDownProvider(t *testing.T) {
+	ports := []string{"COM1"}
+	dd := NewDropDownProvider(func() []Item {
+		items := make([]Item, len(ports))
+		for i, p := range ports {
+			items[i] = Item{Label: p}
+		}
+		return items
+	})
+	if dd.Selected != NoSelection {
+		t.Fatalf("Selected = %d, want NoSelection", dd.Selected)
+	}
+
+	dd.openMenu()
+	dd.handleKey(press(key.NameReturn, 0))
+	if it, _ := dd.SelectedItem(); it.Label != "COM1" {
+		t.Fatalf("selected %q, want COM1", it.Label)
+	}
+
+	ports = []string{"COM0", "COM1", "COM2"}
+	dd.openMenu()
+	if len(dd.Items()) != 3 {
+		t.Fatalf("got %d items, want 3", len(dd.Items()))
+	}
+	if it, _ := dd.SelectedItem(); it.Label != "COM1" {
+		t.Fatalf("selected %q, want COM1", it.Label)
+	}
+}
+
+func TestDropDownProviderLayout(t *testing.T) {
+	th := material.NewTheme(gofont.Collection())
+	dd := NewDropDownProvider(func() []Item {
+		return []Item{{Label: "a"}, {Label: "b"}}
+	})
+
+	gtx := layout.Context{
+		Ops:         new(op.Ops),
+		Constraints: layout.Exact(image.Pt(200, 30)),
+	}
+	// The menu is opened by input handled after the clickables have been
+	// allocated, and laid out in the same frame.
+	dd.update(gtx)
+	dd.openMenu()
+	dd.layoutMenu(th, gtx, gtx)
+	if len(dd.clickables) != 2 {
+		t.Fatalf("got %d clickables, want 2", len(dd.clickables))
+	}
+}
@@ -1,8 +1,13 @@
 package property
 
 import (
+	"fmt"
 	"image"
+	"strings"
 
+	"gioui.org/f32"
+	"gioui.org/gesture"
+	"gioui.org/io/key"
 	"gioui.org/io/pointer"
 	"gioui.org/layout"
 	"gioui.org/op"
@@ -12,6 +17,7 @@ import (
 	"gioui.org/unit"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
+	"gioui.org/x/component"
 	"golang.org/x/exp/constraints"
 )
 
@@ -24,6 +30,9 @@ const (
 	DefaultPropertyHeight  = unit.Dp(30)
 	DefaultHandleBarWidth  = unit.Dp(3)
 	DefaultHandleBarHeight = unit.Dp(35)
+
+	// indentWidth is the width of a nesting level in the name column.
+	indentWidth = unit.Dp(14)
 )
 
 // A List holds and presents a vertical, scrollable list of properties. A List
@@ -33,10 +42,45 @@ const (
 type List struct {
 	widgets []Widget
 	names   []string
+	descs   []string
+
+	// model, if set, provides the properties instead of widgets and names.
+	model *modelWidgets
+
+	// defaults holds the default values of the properties implementing
+	// Stringer.
+	defaults []string
+
+	// rows are the rows shown by the list: the properties and the children
+	// of the expanded ones, rebuilt at every frame. They aren't built for a
+	// model, whose rows are derived from its properties, see row.
+	rows   []row
+	nested bool
+
+	// states are the states of the rows, by path.
+	states map[string]*rowState
+
+	// heights caches the heights of the rows computed during a frame, by
+	// row index.
+	heights map[int]int
+
+	// style is the style of the list being laid out.
+	style *ListStyle
 
-	// PropertyHeight is the height of a single property. All properties have
-	// the same dimensions. The width depends of the horizontal space available
-	// for the list
+	menu contextMenu
+	clip clipboardState
+
+	// described is the path of the row whose description is shown in the
+	// description pane: the hovered row or, if none, the last hovered or
+	// selected one.
+	described string
+
+	// selected is the path of the selected row, or empty.
+	selected string
+
+	// PropertyHeight is the height of a single property. Properties whose
+	// widget implements Sizer can be taller. The width depends of the
+	// horizontal space available for the list
 	PropertyHeight unit.Dp
 
 	// HandleBarWidth is the width of the handlebar used to resize the columns.
@@ -45,15 +89,54 @@ type List struct {
 	// HandleBarHeight is the width of the handlebar.
 	HandleBarHeight unit.Dp
 
-	list layout.List
-
-	// ratio keeps the current layout.
-	// 0 is center, -1 completely to the left, 1 completely to the right.
-	ratio float32
-
-	drag   bool
-	dragID pointer.ID
-	dragX  float32
+	// ContextActions, if set, returns additional entries for the context
+	// menu opened by right-clicking the row of the property at path. The
+	// path of a child property is made of the names of its ancestors, such as
+	// "points[0].x".
+	ContextActions func(path string) []Action
+
+	// DescriptionHeight is the height of the pane shown at the bottom of the
+	// list, with the name and description of the hovered property. If 0, no
+	// pane is shown. Descriptions are also shown as tooltips.
+	DescriptionHeight unit.Dp
+
+	// OnSelect, if set, is called with the path of the selected row when the
+	// user selects another row, by clicking it or giving it the keyboard
+	// focus.
+	OnSelect func(path string)
+
+	// Columns defines the widths of the columns. It's updated when the user
+	// drags the divider between the columns, double-clicks it to fit the
+	// names, or moves it with the arrow keys once focused.
+	Columns Columns
+
+	// Scrollbar shows a scrollbar on the right of the rows. It's disabled by
+	// default.
+	Scrollbar bool
+
+	// OnError, if set, is called with the errors of the clipboard operations
+	// requested by the user, such as pasting invalid values.
+	OnError func(err error)
+
+	// PopupArea is the area where popups, such as menus, can be shown,
+	// relative to the list. If empty, popups are kept within the list. To
+	// let them overflow the list, set it to the bounds of the window, offset
+	// by the position of the list in the window.
+	PopupArea image.Rectangle
+
+	// Invalidator, if set, is called to trigger a new frame when updates
+	// are posted with Update. Since it's read by the goroutines calling
+	// Update, it must be set before the list is laid out or any update is
+	// posted, and not changed afterwards.
+	Invalidator Invalidator
+
+	updates updateQueue
+
+	// scrollTo, if set, matches the row to scroll to at next frame.
+	scrollTo func(r row) bool
+
+	list    widget.List
+	divider divider
 }
 
 // NewList creates a new List.
@@ -62,108 +145,441 @@ func NewList() *List {
 		PropertyHeight:  DefaultPropertyHeight,
 		HandleBarWidth:  DefaultHandleBarWidth,
 		HandleBarHeight: DefaultHandleBarHeight,
-		list: layout.List{
-			Axis: layout.Vertical,
+		list: widget.List{
+			List: layout.List{
+				Axis: layout.Vertical,
+			},
 		},
 	}
 }
 
 // Add adds a new property to the list.
 func (plist *List) Add(name string, widget Widget) {
+	plist.AddWithDescription(name, "", widget)
+}
+
+// AddWithDescription adds a new property to the list, with a description of
+// the property shown as a tooltip of its name and in the description pane.
+func (plist *List) AddWithDescription(name, desc string, widget Widget) {
 	plist.widgets = append(plist.widgets, widget)
 	plist.names = append(plist.names, name)
+	plist.descs = append(plist.descs, desc)
+	plist.defaults = append(plist.defaults, "")
+	plist.setDefault(len(plist.widgets) - 1)
+}
+
+// index returns the index of the first property having the given name, or -1.
+func (plist *List) index(name string) int {
+	for i, n := 0, plist.len(); i < n; i++ {
+		if plist.name(i) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// withDefault returns the widget of the property at index i if it has a default
+// value. Secrets don't, so that their value isn't kept elsewhere.
+func (plist *List) withDefault(i int) (Stringer, bool) {
+	if _, ok := plist.widgets[i].(plainTexter); ok {
+		return nil, false
+	}
+	s, ok := plist.widgets[i].(Stringer)
+	return s, ok
+}
+
+// setDefault takes the current value of the property at index i as its
+// default value.
+func (plist *List) setDefault(i int) {
+	if s, ok := plist.withDefault(i); ok {
+		plist.defaults[i] = s.String()
+	}
+}
+
+// SetDefaults takes the current values of all properties as their default
+// values. The default value of a property is its value when it's added to the
+// list. Only properties implementing Stringer have a default value, except
+// secrets which are never reset. Properties of a model have no default value,
+// so SetDefaults does nothing while a model is set.
+func (plist *List) SetDefaults() {
+	if plist.model != nil {
+		return
+	}
+	for i := range plist.widgets {
+		plist.setDefault(i)
+	}
+}
+
+func (plist *List) modified(i int) bool {
+	if plist.model != nil {
+		return false
+	}
+	s, ok := plist.withDefault(i)
+	return ok && s.String() != plist.defaults[i]
+}
+
+// Modified reports whether the property with the given name has a value
+// different from its default value.
+func (plist *List) Modified(name string) bool {
+	i := plist.index(name)
+	return i >= 0 && plist.modified(i)
+}
+
+func (plist *List) reset(i int) error {
+	if !plist.modified(i) {
+		return nil
+	}
+	return plist.widgets[i].(Stringer).Set(plist.defaults[i])
+}
+
+// Reset restores the default value of the property with the given name.
+func (plist *List) Reset(name string) error {
+	i := plist.index(name)
+	if i < 0 {
+		return fmt.Errorf("no property %q", name)
+	}
+	return plist.reset(i)
+}
+
+// ResetAll restores the default values of all properties. It returns the
+// first error encountered, if any, after having reset all the others. It does
+// nothing while a model is set.
+func (plist *List) ResetAll() error {
+	if plist.model != nil {
+		return nil
+	}
+	var err error
+	for i := range plist.widgets {
+		if rerr := plist.reset(i); rerr != nil && err == nil {
+			err = fmt.Errorf("%s: %w", plist.names[i], rerr)
+		}
+	}
+	return err
+}
+
+// Selected returns the path of the selected row, or an empty string if no row
+// is selected. The selection is kept when the focus moves out of the list.
+func (plist *List) Selected() string {
+	return plist.selected
+}
+
+// SetSelected selects the row of the property at path, or clears the selection
+// if path is empty. The path of a child property is made of the names of its
+// ancestors, such as "points[0].x". OnSelect isn't called.
+func (plist *List) SetSelected(path string) {
+	plist.selected = path
+}
+
+// selectRow selects r following a user action.
+func (plist *List) selectRow(r row) {
+	plist.described = r.path
+	if plist.selected == r.path {
+		return
+	}
+	plist.selected = r.path
+	if plist.OnSelect != nil {
+		plist.OnSelect(r.path)
+	}
 }
 
 func (plist *List) visibleHeight(gtx C) int {
-	return min(gtx.Dp(plist.PropertyHeight)*len(plist.widgets), gtx.Constraints.Max.Y)
+	maxh := gtx.Constraints.Max.Y - gtx.Dp(plist.DescriptionHeight)
+	h := 0
+	for i, n := 0, plist.nrows(); i < n && h < maxh; i++ {
+		h += plist.rowHeight(gtx, i)
+	}
+	return max(0, min(h, maxh))
+}
+
+// Sizer is implemented by property widgets which need a row taller than
+// List.PropertyHeight, such as multi-line texts or images.
+type Sizer interface {
+	Widget
+
+	// Height returns the height, in pixels, of the widget laid out with
+	// the width gtx.Constraints.Max.X. Rows are never smaller than
+	// List.PropertyHeight.
+	Height(gtx C) int
+}
+
+// rowHeight returns the height of the row at index i, in a list as wide as
+// gtx maximum constraints.
+func (plist *List) rowHeight(gtx C, i int) int {
+	if h, ok := plist.heights[i]; ok {
+		return h
+	}
+	h := gtx.Dp(plist.PropertyHeight)
+	measure := func(w Widget) {
+		if s, ok := w.(Sizer); ok {
+			_, _, rsize := plist.columns(gtx, gtx.Constraints.Max.X)
+			gtx.Constraints = layout.Constraints{
+				Min: image.Pt(max(0, rsize), h),
+				Max: image.Pt(max(0, rsize), 1e6),
+			}
+			h = max(h, s.Height(gtx))
+		}
+	}
+	// Rows of a model are measured before their widget is laid out.
+	if plist.model != nil {
+		plist.model.peek(i, measure)
+	} else {
+		measure(plist.rows[i].w)
+	}
+	if plist.heights == nil {
+		plist.heights = make(map[int]int)
+	}
+	plist.heights[i] = h
+	return h
+}
+
+// rowOffset returns the vertical position of the row at index i relative to
+// the one at index first.
+func (plist *List) rowOffset(gtx C, i, first int) int {
+	y := 0
+	for j := first; j < i; j++ {
+		y += plist.rowHeight(gtx, j)
+	}
+	for j := i; j < first; j++ {
+		y -= plist.rowHeight(gtx, j)
+	}
+	return y
+}
+
+// row is a row of the list, showing a property or the child of a property.
+type row struct {
+	// prop is the index of the property shown by the row, or -1 for the
+	// children of properties. top is the index of the property, or of the
+	// top-level parent property.
+	prop  int
+	top   int
+	name  string
+	path  string
+	desc  string
+	label Widget
+	w     Widget
+	depth int
+}
+
+// title returns the name shown to the user: the string of the label if it
+// has one, such as the key of a Map pair, or the name of the row.
+func (r row) title() string {
+	if s, ok := r.label.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return r.name
+}
+
+// rowState is the state of a row, kept across frames.
+type rowState struct {
+	toggle  gesture.Click
+	reset   widget.Clickable
+	tip     component.TipArea
+	hovered bool
+
+	// focused reports whether the row has the keyboard focus, which is given
+	// by clicking its name.
+	focused bool
+	click   gesture.Click
+}
+
+// state returns the state of the row at path.
+func (plist *List) state(path string) *rowState {
+	if plist.states == nil {
+		plist.states = make(map[string]*rowState)
+	}
+	st, ok := plist.states[path]
+	if !ok {
+		st = new(rowState)
+		plist.states[path] = st
+	}
+	return st
+}
+
+// forgetStates forgets the state of the row at path and of its children.
+func (plist *List) forgetStates(path string) {
+	for p := range plist.states {
+		if p == path || strings.HasPrefix(p, path+".") || strings.HasPrefix(p, path+"[") {
+			delete(plist.states, p)
+		}
+	}
+}
+
+// nrows returns the number of rows.
+func (plist *List) nrows() int {
+	if plist.model != nil {
+		return plist.model.m.Len()
+	}
+	return len(plist.rows)
+}
+
+// row returns the row at index i. Each property of a model is a row, whose
+// widget is only known once created for a visible row.
+func (plist *List) row(i int) row {
+	if plist.model == nil {
+		return plist.rows[i]
+	}
+	name := plist.name(i)
+	return row{prop: i, top: i, name: name, path: name, desc: plist.desc(i), w: plist.model.get(i)}
+}
+
+// builtRows returns the range of the rows which can be searched without
+// building them: all of them, or for a model the ones laid out during the
+// last frame.
+func (plist *List) builtRows() (first, last int) {
+	if plist.model == nil {
+		return 0, len(plist.rows)
+	}
+	pos := plist.list.Position
+	return pos.First, min(pos.First+pos.Count, plist.nrows())
+}
+
+func (plist *List) buildRows() {
+	plist.rows = plist.rows[:0]
+	plist.nested = false
+	for i := range plist.heights {
+		delete(plist.heights, i)
+	}
+	if plist.model != nil {
+		// The states of the rows of a model are forgotten with their
+		// widgets, at the end of the frame.
+		return
+	}
+
+	for i, w := range plist.widgets {
+		if _, ok := w.(Expander); ok {
+			plist.nested = true
+		}
+		name := plist.names[i]
+		plist.rows = appendRows(plist.rows, row{prop: i, top: i, name: name, path: name, desc: plist.descs[i], w: w})
+	}
+
+	// Forget the state of the rows which aren't shown anymore.
+	if len(plist.states) > len(plist.rows) {
+		shown := make(map[string]bool, len(plist.rows))
+		for _, r := range plist.rows {
+			shown[r.path] = true
+		}
+		for p := range plist.states {
+			if !shown[p] {
+				delete(plist.states, p)
+			}
+		}
+	}
+}
+
+// childPath returns the path of the child property with the given name, such
+// as "points[0].x".
+func childPath(parent, name string) string {
+	if strings.HasPrefix(name, "[") {
+		return parent + name
+	}
+	return parent + "." + name
 }
 
+// appendRows appends r to rows, followed by its children if r is an expanded
+// Expander.
+func appendRows(rows []row, r row) []row {
+	rows = append(rows, r)
+	if e, ok := r.w.(Expander); ok && e.Expanded() {
+		for _, c := range e.Children() {
+			rows = appendRows(rows, row{
+				prop:  -1,
+				top:   r.top,
+				name:  c.Name,
+				path:  childPath(r.path, c.Name),
+				desc:  c.Description,
+				label: c.Label,
+				w:     c.Widget,
+				depth: r.depth + 1,
+			})
+		}
+	}
+	return rows
+}
+
+// Layout lays out the list with the default style for th.
 func (plist *List) Layout(th *material.Theme, gtx C) D {
-	proportion := (plist.ratio + 1) / 2
-	whandle := gtx.Dp(plist.HandleBarWidth)
-	lsize := int(proportion*float32(gtx.Constraints.Max.X)) - whandle
-	roff := lsize + whandle
+	return DefaultStyle(th, plist).Layout(gtx)
+}
+
+func (plist *List) layout(s *ListStyle, gtx C) D {
+	plist.style = s
+	th := s.Theme
+	plist.applyUpdates()
+	plist.buildRows()
+	plist.updateClipboard(gtx)
 
-	htotal := plist.visibleHeight(gtx)
+	plist.scroll()
+
+	// Rows are narrower than the list when the scrollbar is shown.
+	rgtx := gtx
+	rgtx.Constraints.Max.X = plist.rowsWidth(gtx)
+	lsize, roff, _ := plist.columns(rgtx, rgtx.Constraints.Max.X)
+	whandle := roff - lsize
+
+	htotal := plist.visibleHeight(rgtx)
 	hhandle := gtx.Dp(plist.HandleBarHeight)
 	barrect := image.Rect(lsize, (htotal-hhandle)/2, roff, (htotal+hhandle)/2)
 
 	dim := widget.Border{
-		Color:        th.Fg,
+		Color:        s.Border,
 		CornerRadius: unit.Dp(2),
 		Width:        unit.Dp(1),
 	}.Layout(gtx, func(gtx C) D {
-		return layout.Stack{}.Layout(gtx,
-			layout.Stacked(func(gtx C) D {
-				// Copy the context passed to property widgets, we don't want
-				// its size constrained since it's used as modal pane.
-				pgtx := gtx
-				gtx.Constraints = layout.Exact(image.Pt(gtx.Constraints.Max.X, htotal))
-				return plist.list.Layout(gtx, len(plist.widgets), func(gtx C, i int) D {
-					gtx.Constraints.Min.Y = gtx.Dp(plist.PropertyHeight)
-					gtx.Constraints.Max.Y = gtx.Dp(plist.PropertyHeight)
-					return plist.layoutProperty(i, th, pgtx, gtx)
-				})
+		// Copy the context passed to property widgets, we don't want its size
+		// constrained since it's used as modal pane.
+		pgtx := gtx
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(func(gtx C) D {
+				return layout.Stack{}.Layout(gtx,
+					layout.Stacked(func(gtx C) D {
+						gtx.Constraints = layout.Exact(image.Pt(gtx.Constraints.Max.X, htotal))
+						return plist.layoutRows(th, gtx, func(gtx C, i int) D {
+							h := plist.rowHeight(gtx, i)
+							gtx.Constraints.Min.Y = h
+							gtx.Constraints.Max.Y = h
+							// Vertical position of the property relative to
+							// the parent context. While the list is being
+							// laid out, the child at index First is at
+							// -Offset.
+							pos := plist.list.Position
+							y := plist.rowOffset(gtx, i, pos.First) - pos.Offset
+							return plist.layoutProperty(i, y, th, pgtx, gtx)
+						})
+					}),
+					layout.Stacked(func(gtx C) D {
+						// Draw divider line
+						xdiv := lsize + whandle/2
+						paint.FillShape(gtx.Ops, s.Divider, clip.Rect{
+							Min: image.Pt(xdiv, 0),
+							Max: image.Pt(xdiv+1, htotal),
+						}.Op())
+						// Draw handlebar
+						paint.FillShape(gtx.Ops, s.Handle, clip.Rect(barrect).Op())
+						return D{}
+					}),
+					layout.Expanded(func(gtx C) D {
+						return plist.layoutContextMenu(th, gtx)
+					}),
+				)
 			}),
-			layout.Stacked(func(gtx C) D {
-				// Draw divider line
-				xdiv := lsize + whandle/2
-				paint.FillShape(gtx.Ops, th.ContrastBg, clip.Rect{
-					Min: image.Pt(xdiv, 0),
-					Max: image.Pt(xdiv+1, htotal),
-				}.Op())
-				// Draw handlebar
-				paint.FillShape(gtx.Ops, th.ContrastBg, clip.Rect(barrect).Op())
-				return D{}
+			layout.Rigid(func(gtx C) D {
+				if plist.DescriptionHeight == 0 {
+					return D{}
+				}
+				gtx.Constraints = layout.Exact(image.Pt(gtx.Constraints.Max.X, gtx.Dp(plist.DescriptionHeight)))
+				return plist.layoutDescription(gtx)
 			}),
 		)
 	})
 
-	{
-		// Handle input.
-		for _, ev := range gtx.Events(plist) {
-			e, ok := ev.(pointer.Event)
-			if !ok {
-				continue
-			}
+	plist.layoutHandle(rgtx, barrect)
 
-			switch e.Type {
-			case pointer.Press:
-				if plist.drag {
-					break
-				}
-
-				plist.dragID = e.PointerID
-				plist.dragX = e.Position.X
-
-			case pointer.Drag:
-				if plist.dragID != e.PointerID {
-					break
-				}
-
-				// Clamp drag position so that the 'handle' remains always visible.
-				minposx := whandle
-				maxposx := gtx.Constraints.Max.X - minposx
-				posx := float32(clamp(minposx, int(e.Position.X), maxposx))
-				deltaX := posx - plist.dragX
-				deltaRatio := deltaX * 2 / float32(gtx.Constraints.Max.X)
-
-				plist.dragX = posx
-				plist.ratio += deltaRatio
-
-			case pointer.Release, pointer.Cancel:
-				plist.drag = false
-			}
+	plist.invalidateDeferred(gtx)
+	if plist.model != nil {
+		for _, i := range plist.model.endFrame() {
+			plist.forgetStates(plist.name(i))
 		}
-
-		// Register for receving input in the handlebar rect.
-		defer clip.Rect(barrect).Push(gtx.Ops).Pop()
-		pointer.CursorColResize.Add(gtx.Ops)
-		pointer.InputOp{
-			Tag:   plist,
-			Types: pointer.Press | pointer.Drag | pointer.Release,
-			Grab:  plist.drag,
-		}.Add(gtx.Ops)
 	}
 
 	return dim
@@ -176,6 +592,13 @@ func min[T constraints.Ordered](a, b T) T {
 	return b
 }
 
+func max[T constraints.Ordered](a, b T) T {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 func clamp[T constraints.Ordered](mn, val, mx T) T {
 	if val < mn {
 		return mn
@@ -186,21 +609,122 @@ func clamp[T constraints.Ordered](mn, val, mx T) T {
 	return val
 }
 
-// layoutProperty lays out the property at index i from the list.
-func (plist *List) layoutProperty(idx int, th *material.Theme, pgtx, gtx C) D {
-	proportion := (plist.ratio + 1) / 2
-	whandle := gtx.Dp(plist.HandleBarWidth)
-	lsize := int(proportion*float32(gtx.Constraints.Max.X) - float32(whandle))
+// placed is implemented by the property widgets which use their placement in
+// the List and its style.
+type placed interface {
+	setPlacement(p Placement)
+	setStyle(s *ListStyle)
+}
+
+// listed is embedded by property widgets to implement placed.
+type listed struct {
+	placement Placement
+
+	// style is nil if the widget isn't laid out by a List.
+	style *ListStyle
+}
+
+func (l *listed) setPlacement(p Placement) {
+	l.placement = p
+}
+
+func (l *listed) setStyle(s *ListStyle) {
+	l.style = s
+}
+
+// listStyle returns the style of the List laying out the widget or, if the
+// widget isn't laid out by a List, the default style for th.
+func (l *listed) listStyle(th *material.Theme) *ListStyle {
+	if l.style != nil {
+		return l.style
+	}
+	s := DefaultStyle(th, nil)
+	return &s
+}
+
+// place gives w, if it implements placed, its bounds relative to the parent
+// context pgtx and the style of the list.
+func (plist *List) place(w Widget, bounds image.Rectangle, pgtx C) {
+	p, ok := w.(placed)
+	if !ok {
+		return
+	}
+	area := plist.PopupArea
+	if area.Empty() {
+		area = image.Rectangle{Max: pgtx.Constraints.Max}
+	}
+	p.setPlacement(Placement{Bounds: bounds, Area: area})
+	p.setStyle(plist.style)
+}
 
-	roff := lsize + whandle
-	rsize := gtx.Constraints.Max.X - roff
+// layoutProperty lays out the row at index i from the list, at vertical
+// position y in the parent context.
+func (plist *List) layoutProperty(idx, y int, th *material.Theme, pgtx, gtx C) D {
+	lsize, roff, rsize := plist.columns(gtx, gtx.Constraints.Max.X)
+
+	s := plist.style
+	r := plist.row(idx)
+	if plist.model != nil {
+		// Keep the widget of the property as long as its row is visible.
+		r.w = plist.model.materialize(r.prop)
+	}
+	st := plist.state(r.path)
+	for _, ev := range gtx.Events(&st.hovered) {
+		if e, ok := ev.(pointer.Event); ok {
+			switch e.Type {
+			case pointer.Enter:
+				st.hovered = true
+				plist.described = r.path
+			case pointer.Leave, pointer.Cancel:
+				st.hovered = false
+			case pointer.Press:
+				plist.selectRow(r)
+			}
+		}
+	}
+	plist.handleRowKeys(gtx, r, st)
+
+	// Our key handler encloses the widgets of the row, so that we receive
+	// the copy and paste shortcuts they don't handle.
+	rowArea := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
+	key.InputOp{Tag: &st.focused, Keys: rowKeys}.Add(gtx.Ops)
 
 	{
 		// Draw property name.
 		gtx := gtx
 		size := image.Pt(lsize, gtx.Constraints.Max.Y)
 		gtx.Constraints = layout.Exact(size)
-		plist.LayoutName(idx, th, gtx)
+		name := func(gtx C) D {
+			if r.label == nil {
+				// Clicking the name focuses the row.
+				area := clip.Rect{Max: size}.Push(gtx.Ops)
+				st.click.Add(gtx.Ops)
+				area.Pop()
+				plist.LayoutName(idx, th, gtx)
+			} else {
+				paint.FillShape(gtx.Ops, s.rowBackground(idx), clip.Rect{Max: size}.Op())
+				indent := plist.indent(gtx, r)
+				off := op.Offset(image.Pt(indent, 0)).Push(gtx.Ops)
+				gtx := gtx
+				gtx.Constraints = layout.Exact(image.Pt(max(0, size.X-indent), size.Y))
+				bounds := image.Rectangle{Min: image.Pt(indent, y), Max: image.Pt(size.X, y+size.Y)}
+				plist.place(r.label, bounds, pgtx)
+				r.label.Layout(th, pgtx, gtx)
+				off.Pop()
+			}
+			switch {
+			case r.path == plist.selected:
+				paint.FillShape(gtx.Ops, s.Selected, clip.Rect{Max: size}.Op())
+			case st.hovered:
+				paint.FillShape(gtx.Ops, s.Hovered, clip.Rect{Max: size}.Op())
+			}
+			return D{Size: size}
+		}
+		if r.desc != "" {
+			st.tip.Layout(gtx, component.DesktopTooltip(th, r.desc), name)
+		} else {
+			name(gtx)
+		}
 	}
 	{
 		// Draw property value.
@@ -208,36 +732,242 @@ func (plist *List) layoutProperty(idx int, th *material.Theme, pgtx, gtx C) D {
 		off := op.Offset(image.Pt(roff, 0)).Push(gtx.Ops)
 		size := image.Pt(rsize, gtx.Constraints.Max.Y)
 		gtx.Constraints = layout.Exact(size)
-		plist.widgets[idx].Layout(th, pgtx, gtx)
+		bounds := image.Rectangle{Min: image.Pt(roff, y), Max: image.Pt(roff+rsize, y+size.Y)}
+		plist.place(r.w, bounds, pgtx)
+		r.w.Layout(th, pgtx, gtx)
 		off.Pop()
 	}
+	rowArea.Pop()
 
 	// Draw bottom border.
-	paint.FillShape(gtx.Ops, th.Fg, clip.Rect{
+	paint.FillShape(gtx.Ops, s.Border, clip.Rect{
 		Min: image.Pt(0, gtx.Constraints.Max.Y-1),
 		Max: gtx.Constraints.Max,
 	}.Op())
 
+	if st.focused {
+		widget.Border{Color: s.Focused, Width: unit.Dp(1)}.Layout(gtx, func(gtx C) D {
+			return D{Size: gtx.Constraints.Max}
+		})
+	}
+
+	// Track the hovered and pressed row, without preventing the widgets of
+	// the row to receive pointer events.
+	pass := pointer.PassOp{}.Push(gtx.Ops)
+	area := clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops)
+	pointer.InputOp{Tag: &st.hovered, Types: pointer.Enter | pointer.Leave | pointer.Press}.Add(gtx.Ops)
+	area.Pop()
+	pass.Pop()
+
 	return layout.Dimensions{Size: gtx.Constraints.Max}
 }
 
+// rowKeys are the shortcuts handled by rows: Short-C and Short-V copy and
+// paste the value of the row, or of all rows with Shift.
+const rowKeys = "Short-(Shift)-[C,V]"
+
+func (plist *List) handleRowKeys(gtx C, r row, st *rowState) {
+	for _, e := range st.click.Events(gtx) {
+		if e.Type == gesture.TypePress {
+			key.FocusOp{Tag: &st.focused}.Add(gtx.Ops)
+		}
+	}
+
+	cs := &plist.clip
+	for _, ev := range gtx.Events(&st.focused) {
+		switch e := ev.(type) {
+		case key.FocusEvent:
+			st.focused = e.Focus
+			if e.Focus {
+				plist.selectRow(r)
+			}
+		case key.Event:
+			if e.State != key.Press {
+				break
+			}
+			all := e.Modifiers.Contain(key.ModShift)
+			switch {
+			case e.Name == "C" && all:
+				cs.setCopy(plist.text())
+			case e.Name == "C":
+				if s, ok := r.w.(Stringer); ok {
+					cs.setCopy(s.String())
+				}
+			case e.Name == "V" && all:
+				cs.pasteAll = true
+			case e.Name == "V":
+				cs.setPaste(r)
+			}
+		}
+	}
+}
+
+// layoutDescription lays out the pane showing the name and description of the
+// hovered property, or the last hovered one.
+func (plist *List) layoutDescription(gtx C) D {
+	s := plist.style
+	th := s.Theme
+	paint.FillShape(gtx.Ops, s.Background, clip.Rect{Max: gtx.Constraints.Max}.Op())
+	paint.FillShape(gtx.Ops, s.Border, clip.Rect{Max: image.Pt(gtx.Constraints.Max.X, 1)}.Op())
+
+	var r row
+	first, last := plist.builtRows()
+	for i := first; i < last; i++ {
+		if rr := plist.row(i); rr.path == plist.described {
+			r = rr
+			break
+		}
+	}
+
+	name := material.Label(th, s.TextSize, r.title())
+	name.MaxLines = 1
+	name.Font = s.NameFont
+	name.Font.Weight = text.Bold
+	desc := material.Label(th, s.TextSize, r.desc)
+
+	s.Inset.Layout(gtx, func(gtx C) D {
+		return layout.Flex{Axis: layout.Vertical}.Layout(gtx,
+			layout.Rigid(name.Layout),
+			layout.Flexed(1, desc.Layout),
+		)
+	})
+	return D{Size: gtx.Constraints.Max}
+}
+
+// indent returns the horizontal space taken, in the name column, by the
+// nesting level of a row and, if the list has expandable properties, the
+// expansion toggle.
+func (plist *List) indent(gtx C, r row) int {
+	n := r.depth
+	if plist.nested {
+		n++
+	}
+	return gtx.Dp(indentWidth) * n
+}
+
 func (plist *List) LayoutName(idx int, th *material.Theme, gtx C) D {
-	paint.FillShape(gtx.Ops, th.Bg, clip.Rect{Max: gtx.Constraints.Max}.Op())
+	s := plist.style
+	if s == nil {
+		ds := DefaultStyle(th, plist)
+		s = &ds
+	}
+	paint.FillShape(gtx.Ops, s.rowBackground(idx), clip.Rect{Max: gtx.Constraints.Max}.Op())
+
+	r := plist.row(idx)
+	indent := plist.indent(gtx, r)
+	st := plist.state(r.path)
+	// Properties of a model have no children, see Model.
+	if e, ok := r.w.(Expander); ok && plist.model == nil {
+		plist.layoutToggle(gtx, s, e, st, indent)
+	}
 
-	label := material.Label(th, th.TextSize, plist.names[idx])
+	label := material.Label(th, s.TextSize, r.name)
 	label.MaxLines = 1
-	label.TextSize = th.TextSize
-	label.Font.Weight = 50
+	label.Font = s.NameFont
 	label.Alignment = text.Start
 
-	inset := layout.Inset{Top: 1, Right: 4, Bottom: 1, Left: 4}
-	return inset.Layout(gtx, label.Layout)
+	for st.reset.Clicked() {
+		if r.prop >= 0 {
+			plist.reset(r.prop)
+		}
+	}
+	modified := r.prop >= 0 && plist.modified(r.prop)
+	if modified {
+		// Mark modified properties with a bar on the left and a bold name.
+		label.Font.Weight = text.Bold
+		bar := image.Rect(0, 0, gtx.Dp(2), gtx.Constraints.Max.Y)
+		paint.FillShape(gtx.Ops, s.Accent, clip.Rect(bar).Op())
+	}
+
+	defer op.Offset(image.Pt(indent, 0)).Push(gtx.Ops).Pop()
+	gtx.Constraints = layout.Exact(image.Pt(max(0, gtx.Constraints.Max.X-indent), gtx.Constraints.Max.Y))
+	layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return s.Inset.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !modified {
+				return D{}
+			}
+			return layoutSquareButton(s, gtx, &st.reset, "↺")
+		}),
+	)
+	return D{Size: gtx.Constraints.Max}
+}
+
+// layoutToggle lays out the button expanding or collapsing the children of e,
+// on the left of the property name, which starts at x position indent. st is
+// the state of the row of e.
+func (plist *List) layoutToggle(gtx C, s *ListStyle, e Expander, st *rowState, indent int) {
+	click := &st.toggle
+	for _, ev := range click.Events(gtx) {
+		if ev.Type == gesture.TypeClick {
+			e.SetExpanded(!e.Expanded())
+		}
+	}
+
+	w := gtx.Dp(indentWidth)
+	rect := image.Rect(indent-w, 0, indent, gtx.Constraints.Max.Y)
+	area := clip.Rect(rect).Push(gtx.Ops)
+	pointer.CursorPointer.Add(gtx.Ops)
+	click.Add(gtx.Ops)
+	area.Pop()
+
+	// Draw a triangle pointing right when collapsed, down when expanded.
+	sz := float32(w) / 2
+	c := layout.FPt(rect.Min.Add(rect.Max)).Mul(0.5)
+	var p clip.Path
+	p.Begin(gtx.Ops)
+	if e.Expanded() {
+		p.MoveTo(c.Add(f32.Pt(-sz/2, -sz/4)))
+		p.LineTo(c.Add(f32.Pt(sz/2, -sz/4)))
+		p.LineTo(c.Add(f32.Pt(0, sz/4)))
+	} else {
+		p.MoveTo(c.Add(f32.Pt(-sz/4, -sz/2)))
+		p.LineTo(c.Add(f32.Pt(sz/4, 0)))
+		p.LineTo(c.Add(f32.Pt(-sz/4, sz/2)))
+	}
+	p.Close()
+	paint.FillShape(gtx.Ops, s.Muted, clip.Outline{Path: p.End()}.Op())
+}
+
+// Expander is implemented by property widgets having child properties, which
+// a List shows as indented rows below the property when it's expanded.
+type Expander interface {
+	Widget
+
+	// Expanded reports whether the children are shown.
+	Expanded() bool
+
+	// SetExpanded shows or hides the children.
+	SetExpanded(expanded bool)
+
+	// Children returns the child properties, in display order.
+	Children() []Child
+}
+
+// Child is a child property of an Expander.
+type Child struct {
+	// Name is the name of the property.
+	Name string
+
+	// Label, if not nil, is laid out in the name column instead of Name.
+	Label Widget
+
+	// Description describes the property, see List.AddWithDescription.
+	Description string
+
+	// Widget shows the value of the property.
+	Widget Widget
 }
 
 // Widget shows the value of a property and handles user actions to edit it.
 type Widget interface {
 	// Layout lays out the property widget using gtx which is the
 	// property-specific context, and pgtx which is the parent context (useful
-	// for properties that require more space during edition).
+	// for properties that require more space during edition). When laid out
+	// by a List, the widgets of this package are also given their position
+	// within the list, which Popup uses.
 	Layout(th *material.Theme, pgtx, gtx layout.Context) D
 }
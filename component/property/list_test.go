@@ -0,0 +1,371 @@
+package property
+
+import (
+	"image"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gioui.org/font/gofont"
+	"gioui.org/io/clipboard"
+	"gioui.org/io/router"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget/material"
+	"golang.org/x/exp/slices"
+)
+
+func TestListRows(t *testing.T) {
+	inner := newIntSlice(1, 2)
+	strs := NewSlice([]string{"x"}, func(s string) Value[string] { return NewString(s) })
+
+	plist := NewList()
+	plist.Add("a", NewInt(0))
+	plist.Add("b", inner)
+	plist.AddWithDescription("c", "strings", strs)
+
+	names := func() []string {
+		plist.buildRows()
+		var names []string
+		for _, r := range plist.rows {
+			names = append(names, r.name)
+		}
+		return names
+	}
+
+	if got, want := names(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	inner.SetExpanded(true)
+	if got, want := names(), []string{"a", "b", "[0]", "[1]", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("rows = %v, want %v", got, want)
+	}
+	if !plist.nested || plist.rows[2].depth != 1 {
+		t.Fatalf("children should be nested")
+	}
+	if got := plist.rows[4].desc; got != "strings" {
+		t.Fatalf("description = %q, want %q", got, "strings")
+	}
+}
+
+func TestListDefaults(t *testing.T) {
+	i := NewInt(1)
+	dd := NewDropDown([]string{"a", "b"})
+	secret := NewSecret("s3cr3t")
+	live := NewLive(func() string { return "live" })
+
+	plist := NewList()
+	plist.Add("int", i)
+	plist.Add("dropdown", dd)
+	plist.Add("secret", secret)
+	plist.Add("live", live)
+
+	i.SetValue(2)
+	dd.Selected = 1
+	secret.SetValue("changed")
+	for _, name := range []string{"int", "dropdown"} {
+		if !plist.Modified(name) {
+			t.Errorf("Modified(%q) = false, want true", name)
+		}
+	}
+	// Secrets have no default value.
+	for _, name := range []string{"secret", "live"} {
+		if plist.Modified(name) {
+			t.Errorf("Modified(%q) = true, want false", name)
+		}
+	}
+
+	if err := plist.ResetAll(); err != nil {
+		t.Fatalf("ResetAll() = %v", err)
+	}
+	if i.Value() != 1 || dd.String() != "a" || secret.Value() != "changed" {
+		t.Fatalf("values not reset: %d, %q, %q", i.Value(), dd.String(), secret.Value())
+	}
+
+	i.SetValue(3)
+	plist.SetDefaults()
+	if plist.Modified("int") {
+		t.Fatalf("Modified(%q) = true after SetDefaults, want false", "int")
+	}
+	if err := plist.Reset("unknown"); err == nil {
+		t.Fatalf("Reset(%q) should fail", "unknown")
+	}
+}
+
+func TestListContextActions(t *testing.T) {
+	i := NewInt(1)
+	pts := newIntSlice(4, 5)
+	pts.SetExpanded(true)
+
+	plist := NewList()
+	plist.Add("int", i)
+	plist.Add("slice", pts)
+	plist.ContextActions = func(path string) []Action {
+		return []Action{{Label: "Inspect " + path}}
+	}
+	plist.buildRows()
+
+	labels := func(r row) []string {
+		var labels []string
+		for _, a := range plist.contextActions(r) {
+			labels = append(labels, a.Label)
+		}
+		return labels
+	}
+
+	want := []string{"Copy value", "Paste value", "Copy name", "Copy all", "Paste all", "Inspect int"}
+	if got := labels(plist.rows[0]); !slices.Equal(got, want) {
+		t.Fatalf("actions = %v, want %v", got, want)
+	}
+	i.SetValue(2)
+	want = []string{"Copy value", "Paste value", "Reset", "Copy name", "Copy all", "Paste all", "Inspect int"}
+	if got := labels(plist.rows[0]); !slices.Equal(got, want) {
+		t.Fatalf("actions = %v, want %v", got, want)
+	}
+	want = []string{"Copy value", "Paste value", "Copy name", "Copy path", "Copy all", "Paste all", "Inspect slice[1]"}
+	if got := labels(plist.rows[3]); !slices.Equal(got, want) {
+		t.Fatalf("actions = %v, want %v", got, want)
+	}
+
+	plist.contextActions(plist.rows[0])[0].Do()
+	if plist.clip.copy == nil || *plist.clip.copy != "2" {
+		t.Fatalf("Copy value should copy %q", "2")
+	}
+
+	paste(i, "42")
+	if i.Value() != 42 || i.Err() != nil {
+		t.Fatalf("pasted value = %d (err %v), want 42", i.Value(), i.Err())
+	}
+	paste(i, "foo")
+	if i.Value() != 42 || i.Err() == nil {
+		t.Fatalf("pasting an invalid value should keep 42 and fail")
+	}
+}
+
+func TestListSelection(t *testing.T) {
+	pts := newIntSlice(4, 5)
+	pts.SetExpanded(true)
+
+	plist := NewList()
+	plist.Add("int", NewInt(1))
+	plist.Add("slice", pts)
+	plist.buildRows()
+
+	var selected []string
+	plist.OnSelect = func(path string) { selected = append(selected, path) }
+
+	plist.selectRow(plist.rows[0])
+	plist.selectRow(plist.rows[0])
+	plist.selectRow(plist.rows[3])
+	if got := plist.Selected(); got != "slice[1]" {
+		t.Fatalf("Selected() = %q, want %q", got, "slice[1]")
+	}
+	if want := []string{"int", "slice[1]"}; !slices.Equal(selected, want) {
+		t.Fatalf("OnSelect called with %v, want %v", selected, want)
+	}
+
+	// The selection survives collapsing and expanding its parent.
+	pts.SetExpanded(false)
+	plist.buildRows()
+	pts.SetExpanded(true)
+	plist.buildRows()
+	if got := plist.Selected(); got != "slice[1]" {
+		t.Fatalf("Selected() = %q, want %q", got, "slice[1]")
+	}
+
+	plist.SetSelected("")
+	if got := plist.Selected(); got != "" {
+		t.Fatalf("Selected() = %q, want none", got)
+	}
+	if len(selected) != 2 {
+		t.Fatalf("SetSelected shouldn't call OnSelect")
+	}
+}
+
+type sizedWidget struct {
+	Widget
+	h int
+}
+
+func (w sizedWidget) Height(gtx C) int {
+	return w.h
+}
+
+func TestListRowHeights(t *testing.T) {
+	plist := NewList()
+	plist.PropertyHeight = 30
+	plist.Add("a", NewInt(0))
+	plist.Add("b", sizedWidget{Widget: NewInt(1), h: 100})
+	plist.Add("c", sizedWidget{Widget: NewInt(2), h: 10})
+	plist.Add("d", NewInt(3))
+	plist.buildRows()
+
+	gtx := C{Constraints: layout.Exact(image.Pt(200, 1000))}
+	for i, want := range []int{30, 100, 30, 30} {
+		if got := plist.rowHeight(gtx, i); got != want {
+			t.Errorf("row %d height = %d, want %d", i, got, want)
+		}
+	}
+	if got := plist.rowOffset(gtx, 3, 1); got != 130 {
+		t.Errorf("offset of row 3 from row 1 = %d, want 130", got)
+	}
+	if got := plist.rowOffset(gtx, 0, 2); got != -130 {
+		t.Errorf("offset of row 0 from row 2 = %d, want -130", got)
+	}
+	if got := plist.visibleHeight(gtx); got != 190 {
+		t.Errorf("visible height = %d, want 190", got)
+	}
+	gtx.Constraints.Max.Y = 120
+	if got := plist.visibleHeight(gtx); got != 120 {
+		t.Errorf("visible height = %d, want 120", got)
+	}
+
+	// Rows of a model are measured before their widgets are created.
+	plist.SetModel(sizedModel{30, 100, 10})
+	plist.buildRows()
+	for i, want := range map[int]int{0: 30, 1: 100, 2: 30} {
+		if got := plist.rowHeight(gtx, i); got != want {
+			t.Errorf("model row %d height = %d, want %d", i, got, want)
+		}
+	}
+	if len(plist.model.live) != 0 {
+		t.Errorf("measuring rows created %d widgets", len(plist.model.live))
+	}
+}
+
+// sizedModel is a model of properties having the given heights.
+type sizedModel []int
+
+func (m sizedModel) Len() int            { return len(m) }
+func (m sizedModel) Name(i int) string   { return strconv.Itoa(i) }
+func (m sizedModel) Release(int, Widget) {}
+
+func (m sizedModel) Widget(i int, recycled Widget) Widget {
+	return sizedWidget{Widget: NewInt(i), h: m[i]}
+}
+
+// testTheme is the theme of the lists laid out by layoutFrame.
+var testTheme = material.NewTheme(gofont.Collection())
+
+// layoutFrame lays out plist in a 300x300 frame. If r isn't nil, it routes
+// the events of the frame, otherwise the frame has no event queue.
+func layoutFrame(t *testing.T, plist *List, r *router.Router) {
+	t.Helper()
+	gtx := layout.Context{
+		Ops:         new(op.Ops),
+		Constraints: layout.Exact(image.Pt(300, 300)),
+	}
+	if r != nil {
+		gtx.Queue = r
+	}
+	plist.Layout(testTheme, gtx)
+	if r != nil {
+		r.Frame(gtx.Ops)
+	}
+}
+
+// widgetFunc is a widget of a non-comparable type.
+type widgetFunc func(th *material.Theme, pgtx, gtx C) D
+
+func (f widgetFunc) Layout(th *material.Theme, pgtx, gtx C) D { return f(th, pgtx, gtx) }
+
+func TestListNonComparableWidget(t *testing.T) {
+	var laidOut int
+	w := widgetFunc(func(th *material.Theme, pgtx, gtx C) D {
+		laidOut++
+		return D{Size: gtx.Constraints.Max}
+	})
+	plist := NewList()
+	plist.Add("func", w)
+
+	var updated bool
+	plist.Update(w, func() { updated = true })
+	layoutFrame(t, plist, nil)
+	if laidOut != 1 || !updated {
+		t.Errorf("laid out %d times, updated: %v", laidOut, updated)
+	}
+}
+
+func TestListPaste(t *testing.T) {
+	i := NewInt(1)
+	ticks := NewInt(5)
+	ticks.Editable = false
+
+	plist := NewList()
+	plist.Add("int", i)
+	plist.Add("ticks", ticks)
+	var errs []error
+	plist.OnError = func(err error) { errs = append(errs, err) }
+
+	var r router.Router
+	frame := func() { layoutFrame(t, plist, &r) }
+	clip := func(s string) {
+		frame()
+		r.Queue(clipboard.Event{Text: s})
+		frame()
+	}
+	frame()
+
+	for _, a := range plist.contextActions(plist.rows[1]) {
+		if a.Label == "Paste value" {
+			t.Errorf("Paste value offered for a read-only property")
+		}
+	}
+	plist.clip.setPaste(plist.rows[1])
+	if plist.clip.pasteTo != nil {
+		t.Errorf("paste requested for a read-only property")
+	}
+
+	plist.clip.pasteAll = true
+	clip("int=7\nticks=9\n")
+	if i.Value() != 7 || ticks.Value() != 5 {
+		t.Errorf("after Paste all: int=%d ticks=%d, want 7 and 5", i.Value(), ticks.Value())
+	}
+	if len(errs) != 0 {
+		t.Errorf("Paste all reported %v", errs)
+	}
+
+	plist.clip.setPaste(plist.rows[0])
+	clip("foo")
+	if i.Value() != 7 || len(errs) != 1 || !strings.HasPrefix(errs[0].Error(), "int: ") {
+		t.Errorf("pasting an invalid value: int=%d, errors %v", i.Value(), errs)
+	}
+}
+
+func TestListCopyAll(t *testing.T) {
+	i := NewInt(1)
+	str := NewString("line 1\nline 2")
+	plist := NewList()
+	plist.Add("int", i)
+	plist.Add("a=b", str)
+
+	var r router.Router
+	frame := func() { layoutFrame(t, plist, &r) }
+	frame()
+
+	action := func(label string) {
+		for _, a := range plist.contextActions(plist.rows[0]) {
+			if a.Label == label {
+				a.Do()
+				return
+			}
+		}
+		t.Fatalf("no %q action", label)
+	}
+	action("Copy all")
+	frame()
+	text, ok := r.WriteClipboard()
+	if !ok {
+		t.Fatalf("nothing copied")
+	}
+
+	i.SetValue(2)
+	str.SetValue("changed")
+	action("Paste all")
+	frame()
+	r.Queue(clipboard.Event{Text: text})
+	frame()
+	if i.Value() != 1 || str.Value() != "line 1\nline 2" {
+		t.Errorf("pasted values: %d, %q from %q", i.Value(), str.Value(), text)
+	}
+}
@@ -0,0 +1,165 @@
+package property
+
+import (
+	"math"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+)
+
+// Live is a read-only property whose value is computed by a function, which
+// is evaluated again at every frame or, if Interval is set, at most once per
+// Interval. It's laid out as a non-editable Text. A Live property created
+// with NewLiveFloat64 can also draw the history of its values as a sparkline.
+type Live struct {
+	// Interval is the minimum duration between 2 evaluations of the value. If
+	// 0, the value is evaluated at every frame and a new frame is requested
+	// right away, so the window is redrawn continuously: set an Interval
+	// unless the value must follow every frame.
+	Interval time.Duration
+
+	// History is the number of past values drawn as a sparkline behind the
+	// text. If 0 no sparkline is drawn. Only numeric properties have a
+	// history.
+	History int
+
+	// RowHeight, if larger than List.PropertyHeight, is the height of the
+	// row showing the property, giving more room to the sparkline.
+	RowHeight unit.Dp
+
+	get func() string
+
+	// getf and f64 are only set for numeric properties.
+	getf func() float64
+	f64  f64val
+
+	text    string
+	samples []float64
+	last    time.Time
+	stale   bool
+	listed
+}
+
+// NewLive creates a Live property showing the string returned by get.
+func NewLive(get func() string) *Live {
+	return &Live{get: get, stale: true}
+}
+
+// NewLiveFloat64 creates a Live property showing the number returned by get.
+func NewLiveFloat64(get func() float64) *Live {
+	l := &Live{
+		getf:  get,
+		f64:   f64val{fmt: defaultFloatFmt, prec: defaultFloatPrec},
+		stale: true,
+	}
+	l.get = func() string {
+		l.f64.val = l.getf()
+		return l.f64.String()
+	}
+	return l
+}
+
+// SetFormat sets the format to use when converting the numeric value to
+// string, as per strconv.FormatFloat(). It has no effect on properties not
+// created with NewLiveFloat64.
+func (l *Live) SetFormat(fmt byte, prec int) {
+	l.f64.fmt = fmt
+	l.f64.prec = prec
+}
+
+// Refresh forces the evaluation of the value at next frame, regardless of
+// Interval.
+func (l *Live) Refresh() {
+	l.stale = true
+}
+
+// String returns the last evaluated value.
+func (l *Live) String() string {
+	return l.text
+}
+
+func (l *Live) update(gtx C) {
+	if l.stale || l.Interval == 0 || gtx.Now.Sub(l.last) >= l.Interval {
+		l.stale = false
+		l.last = gtx.Now
+		l.text = l.get()
+		if l.getf != nil && l.History > 0 {
+			l.samples = append(l.samples, l.f64.val)
+		}
+	}
+	if n := len(l.samples) - l.History; n > 0 {
+		l.samples = append(l.samples[:0], l.samples[n:]...)
+	}
+
+	// Schedule the next evaluation.
+	if l.Interval == 0 {
+		op.InvalidateOp{}.Add(gtx.Ops)
+	} else {
+		op.InvalidateOp{At: l.last.Add(l.Interval)}.Add(gtx.Ops)
+	}
+}
+
+func (l *Live) Height(gtx C) int {
+	return gtx.Dp(l.RowHeight)
+}
+
+func (l *Live) Layout(th *material.Theme, pgtx, gtx C) D {
+	l.update(gtx)
+
+	s := l.listStyle(th)
+	paint.FillShape(gtx.Ops, s.ReadOnly, clip.Rect{Max: gtx.Constraints.Max}.Op())
+	l.layoutSparkline(s, gtx)
+
+	label := material.Label(th, s.TextSize, l.text)
+	label.MaxLines = 1
+	label.Alignment = text.Start
+	label.Color = th.Fg
+
+	return s.focusBorder(false).Layout(gtx, func(gtx C) D {
+		return s.Inset.Layout(gtx, label.Layout)
+	})
+}
+
+// layoutSparkline draws the history of values, scaled so that the min and
+// max values touch the bottom and top of the property.
+func (l *Live) layoutSparkline(s *ListStyle, gtx C) {
+	if len(l.samples) < 2 {
+		return
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range l.samples {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if hi == lo {
+		hi, lo = hi+1, lo-1
+	}
+
+	sz := layout.FPt(gtx.Constraints.Max)
+	margin := float32(gtx.Dp(3))
+	h := sz.Y - 2*margin
+	dx := sz.X / float32(l.History-1)
+	// Right-align samples, so that the most recent is always on the right.
+	x0 := sz.X - dx*float32(len(l.samples)-1)
+	pt := func(i int) f32.Point {
+		y := float32((l.samples[i] - lo) / (hi - lo))
+		return f32.Pt(x0+dx*float32(i), margin+h*(1-y))
+	}
+
+	defer clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops).Pop()
+	var p clip.Path
+	p.Begin(gtx.Ops)
+	p.MoveTo(pt(0))
+	for i := 1; i < len(l.samples); i++ {
+		p.LineTo(pt(i))
+	}
+	paint.FillShape(gtx.Ops, s.Sparkline, clip.Stroke{Path: p.End(), Width: float32(gtx.Dp(1))}.Op())
+}
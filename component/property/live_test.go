@@ -0,0 +1,75 @@
+package property
+
+import (
+	"testing"
+	"time"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"golang.org/x/exp/slices"
+)
+
+func TestLive(t *testing.T) {
+	n := 0
+	l := NewLive(func() string {
+		n++
+		return string(rune('a' + n - 1))
+	})
+	l.Interval = time.Second
+
+	start := time.Now()
+	frame := func(d time.Duration) {
+		l.update(layout.Context{Ops: new(op.Ops), Now: start.Add(d)})
+	}
+
+	tests := []struct {
+		at      time.Duration
+		refresh bool
+		want    string
+	}{
+		{0, false, "a"},
+		{500 * time.Millisecond, false, "a"},
+		{time.Second, false, "b"},
+		{1500 * time.Millisecond, true, "c"},
+		{2 * time.Second, false, "c"},
+		{2500 * time.Millisecond, false, "d"},
+	}
+	for _, tt := range tests {
+		if tt.refresh {
+			l.Refresh()
+		}
+		frame(tt.at)
+		if got := l.String(); got != tt.want {
+			t.Errorf("at %v: got %q, want %q", tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestLiveHistory(t *testing.T) {
+	v := 0.0
+	l := NewLiveFloat64(func() float64 {
+		v++
+		return v
+	})
+	l.SetFormat('f', 0)
+	l.History = 3
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		l.update(layout.Context{Ops: new(op.Ops), Now: start.Add(time.Duration(i) * time.Millisecond)})
+	}
+	if got := l.String(); got != "5" {
+		t.Errorf("got %q, want %q", got, "5")
+	}
+	if want := []float64{3, 4, 5}; !slices.Equal(l.samples, want) {
+		t.Errorf("samples = %v, want %v", l.samples, want)
+	}
+
+	// Strings have no history.
+	s := NewLive(func() string { return "x" })
+	s.History = 3
+	s.update(layout.Context{Ops: new(op.Ops), Now: start})
+	if len(s.samples) != 0 {
+		t.Errorf("string samples = %v, want none", s.samples)
+	}
+}
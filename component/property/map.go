@@ -0,0 +1,219 @@
+package property
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/unit"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"golang.org/x/exp/slices"
+)
+
+// Map is a property holding key/value pairs. Each pair is shown as a child
+// row, with the key edited in the name column and the value in the value
+// column, using the properties created by the key and value factories. When
+// editable, pairs can be added with the '+' button and removed with the button
+// on the right of their keys. Rows having the same key are highlighted.
+type Map[K comparable, V any] struct {
+	Editable bool
+
+	// Less, if set, sorts the pairs by key. Otherwise pairs are shown in the
+	// order they've been added.
+	Less func(a, b K) bool
+
+	newKey   func(K) Value[K]
+	newVal   func(V) Value[V]
+	entries  []*mapEntry[K, V]
+	expanded bool
+	add      widget.Clickable
+	listed
+}
+
+type mapEntry[K comparable, V any] struct {
+	m   *Map[K, V]
+	key Value[K]
+	val Value[V]
+	del widget.Clickable
+
+	// listed is given to the label of the pair.
+	listed
+}
+
+// NewMap creates an empty Map property, using newKey and newVal to create the
+// properties of keys and values.
+func NewMap[K comparable, V any](newKey func(K) Value[K], newVal func(V) Value[V]) *Map[K, V] {
+	return &Map[K, V]{Editable: true, newKey: newKey, newVal: newVal}
+}
+
+// Value returns the current key/value pairs. If several pairs have the same
+// key, the last one wins.
+func (m *Map[K, V]) Value() map[K]V {
+	vals := make(map[K]V, len(m.entries))
+	for _, e := range m.entries {
+		vals[e.key.Value()] = e.val.Value()
+	}
+	return vals
+}
+
+// SetValue replaces all pairs with the ones of vals. Unless Less is set, they
+// are shown in the iteration order of vals, use Set to control the order.
+func (m *Map[K, V]) SetValue(vals map[K]V) {
+	m.entries = m.entries[:0]
+	for k, v := range vals {
+		m.Set(k, v)
+	}
+}
+
+// Set sets the value of the pair having the given key, adding a pair if there
+// is none.
+func (m *Map[K, V]) Set(key K, val V) {
+	for _, e := range m.entries {
+		if e.key.Value() == key {
+			e.val.SetValue(val)
+			return
+		}
+	}
+	m.entries = append(m.entries, &mapEntry[K, V]{m: m, key: m.newKey(key), val: m.newVal(val)})
+}
+
+// Delete removes the pairs having the given key.
+func (m *Map[K, V]) Delete(key K) {
+	entries := m.entries[:0]
+	for _, e := range m.entries {
+		if e.key.Value() != key {
+			entries = append(entries, e)
+		}
+	}
+	m.entries = entries
+}
+
+// Len returns the number of pairs.
+func (m *Map[K, V]) Len() int {
+	return len(m.entries)
+}
+
+// Err returns an error if several pairs have the same key.
+func (m *Map[K, V]) Err() error {
+	seen := make(map[K]bool, len(m.entries))
+	for _, e := range m.entries {
+		k := e.key.Value()
+		if seen[k] {
+			return fmt.Errorf("duplicate key %v", k)
+		}
+		seen[k] = true
+	}
+	return nil
+}
+
+func (m *Map[K, V]) remove(e *mapEntry[K, V]) {
+	if i := slices.Index(m.entries, e); i >= 0 {
+		m.entries = slices.Delete(m.entries, i, i+1)
+	}
+}
+
+// isDup reports whether another pair has the same key as e.
+func (m *Map[K, V]) isDup(e *mapEntry[K, V]) bool {
+	k := e.key.Value()
+	for _, o := range m.entries {
+		if o != e && o.key.Value() == k {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Map[K, V]) Expanded() bool {
+	return m.expanded
+}
+
+func (m *Map[K, V]) SetExpanded(expanded bool) {
+	m.expanded = expanded
+}
+
+// Children returns the pairs. Keys may be duplicated, empty or contain dots,
+// so children are named after the index of their pair, as "[i]", and show
+// their key with their label.
+func (m *Map[K, V]) Children() []Child {
+	entries := m.entries
+	if m.Less != nil {
+		entries = slices.Clone(entries)
+		slices.SortStableFunc(entries, func(a, b *mapEntry[K, V]) bool {
+			return m.Less(a.key.Value(), b.key.Value())
+		})
+	}
+	children := make([]Child, len(entries))
+	for i, e := range entries {
+		children[i] = Child{
+			Name:   fmt.Sprintf("[%d]", slices.Index(m.entries, e)),
+			Label:  (*mapLabel[K, V])(e),
+			Widget: e.val,
+		}
+	}
+	return children
+}
+
+func (m *Map[K, V]) Layout(th *material.Theme, pgtx, gtx C) D {
+	for m.add.Clicked() {
+		if m.Editable {
+			var (
+				k K
+				v V
+			)
+			m.entries = append(m.entries, &mapEntry[K, V]{m: m, key: m.newKey(k), val: m.newVal(v)})
+			m.expanded = true
+		}
+	}
+
+	st := m.listStyle(th)
+	bg := st.ReadOnly
+	if m.Err() != nil {
+		bg = st.Error
+	}
+	return layoutSummary(st, gtx, len(m.entries), bg, m.Editable, &m.add)
+}
+
+// mapLabel lays out the name column of a pair: the key property and a button
+// removing the pair.
+type mapLabel[K comparable, V any] mapEntry[K, V]
+
+// String returns the key, which is the name of the pair shown to the user.
+func (l *mapLabel[K, V]) String() string {
+	return fmt.Sprint(l.key.Value())
+}
+
+func (l *mapLabel[K, V]) Layout(th *material.Theme, pgtx, gtx C) D {
+	e := (*mapEntry[K, V])(l)
+	m := e.m
+	st := e.listStyle(th)
+
+	for e.del.Clicked() {
+		if m.Editable {
+			m.remove(e)
+		}
+	}
+
+	// The key property opens its popups next to the label.
+	if p, ok := e.key.(placed); ok {
+		p.setPlacement(e.placement)
+		p.setStyle(e.style)
+	}
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Flexed(1, func(gtx C) D {
+			gtx.Constraints.Min = gtx.Constraints.Max
+			dims := e.key.Layout(th, pgtx, gtx)
+			if m.isDup(e) {
+				widget.Border{Color: st.ErrorBorder, Width: unit.Dp(2)}.Layout(gtx, func(gtx C) D {
+					return D{Size: gtx.Constraints.Max}
+				})
+			}
+			return dims
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !m.Editable {
+				return D{}
+			}
+			return layoutSquareButton(st, gtx, &e.del, "×")
+		}),
+	)
+}
@@ -0,0 +1,87 @@
+package property
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+func TestMap(t *testing.T) {
+	m := NewMap(
+		func(k string) Value[string] { return NewString(k) },
+		func(v int) Value[int] { return NewInt(v) },
+	)
+	m.Set("b", 1)
+	m.Set("a", 2)
+	m.Set("c", 3)
+	m.Set("a", 4)
+
+	keys := func() []string {
+		var keys []string
+		for _, c := range m.Children() {
+			keys = append(keys, c.Label.(fmt.Stringer).String())
+		}
+		return keys
+	}
+
+	if want := []string{"b", "a", "c"}; !slices.Equal(keys(), want) {
+		t.Fatalf("keys = %v, want %v", keys(), want)
+	}
+	m.Less = func(a, b string) bool { return a < b }
+	if want := []string{"a", "b", "c"}; !slices.Equal(keys(), want) {
+		t.Fatalf("sorted keys = %v, want %v", keys(), want)
+	}
+	// Children are named after their pair, which doesn't move when sorted.
+	var names []string
+	for _, c := range m.Children() {
+		names = append(names, c.Name)
+	}
+	if want := []string{"[1]", "[0]", "[2]"}; !slices.Equal(names, want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	if want := map[string]int{"a": 4, "b": 1, "c": 3}; !maps.Equal(m.Value(), want) {
+		t.Fatalf("Value() = %v, want %v", m.Value(), want)
+	}
+
+	if err := m.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+	m.entries[2].key.SetValue("b")
+	if m.Err() == nil || !m.isDup(m.entries[0]) || m.isDup(m.entries[1]) {
+		t.Fatalf("duplicate key should be detected")
+	}
+
+	m.Delete("b")
+	if want := map[string]int{"a": 4}; !maps.Equal(m.Value(), want) {
+		t.Fatalf("Value() = %v, want %v", m.Value(), want)
+	}
+}
+
+func TestMapPaths(t *testing.T) {
+	m := NewMap(
+		func(k string) Value[string] { return NewString(k) },
+		func(v int) Value[int] { return NewInt(v) },
+	)
+	m.Set("a.b", 1)
+	m.Set("", 2)
+	m.Set("x", 3)
+	m.entries[2].key.SetValue("")
+	m.SetExpanded(true)
+
+	plist := NewList()
+	plist.Add("env", m)
+	plist.buildRows()
+
+	var paths []string
+	for _, r := range plist.rows[1:] {
+		paths = append(paths, r.path)
+	}
+	if want := []string{"env[0]", "env[1]", "env[2]"}; !slices.Equal(paths, want) {
+		t.Fatalf("paths = %v, want %v", paths, want)
+	}
+	if got := plist.rows[1].title(); got != "a.b" {
+		t.Errorf("title = %q, want %q", got, "a.b")
+	}
+}
@@ -0,0 +1,182 @@
+package property
+
+import "gioui.org/layout"
+
+// Model provides the properties of a List whose widgets are created on
+// demand, only for the visible rows. It allows to show very large or lazily
+// computed sets of properties.
+//
+// Widgets of rows which aren't visible anymore are released then recycled,
+// so the model must save the values edited with them when they're released,
+// or with widgets writing their values back to the model data.
+//
+// Each property of a model is a single row: widgets implementing Expander,
+// such as Slice, are shown without their children nor the button expanding
+// them.
+type Model interface {
+	// Len returns the number of properties.
+	Len() int
+
+	// Name returns the name of the property at index i.
+	Name(i int) string
+
+	// Widget returns the widget of the property at index i. recycled, if not
+	// nil, is a widget previously returned by Widget which isn't used
+	// anymore. It can be reused, once fully reset to show the property at
+	// index i, to avoid allocations.
+	Widget(i int, recycled Widget) Widget
+
+	// Release is called when the widget w, returned by Widget for the
+	// property at index i, isn't used anymore, before it's recycled. The
+	// value edited with w should be saved.
+	Release(i int, w Widget)
+}
+
+// Describer can be implemented by a Model to provide descriptions of its
+// properties, see List.AddWithDescription.
+type Describer interface {
+	Description(i int) string
+}
+
+// modelWidgets holds the widgets created by the model of a List.
+type modelWidgets struct {
+	m Model
+
+	// live are the widgets of the rows laid out during the current or the
+	// last frame, by property index.
+	live map[int]*liveWidget
+
+	// free are the widgets which can be recycled.
+	free []Widget
+
+	frame int
+}
+
+type liveWidget struct {
+	w     Widget
+	frame int
+}
+
+// get returns the widget of the property at index i, if it has been created.
+func (mw *modelWidgets) get(i int) Widget {
+	if lw, ok := mw.live[i]; ok {
+		return lw.w
+	}
+	return nil
+}
+
+// materialize returns the widget of the property at index i, creating it if
+// needed, and keeps it until the end of the frame.
+func (mw *modelWidgets) materialize(i int) Widget {
+	lw, ok := mw.live[i]
+	if !ok {
+		if mw.live == nil {
+			mw.live = make(map[int]*liveWidget)
+		}
+		lw = &liveWidget{w: mw.m.Widget(i, mw.recycled())}
+		mw.live[i] = lw
+	}
+	lw.frame = mw.frame
+	return lw.w
+}
+
+// peek calls f with the widget of the property at index i, creating a
+// temporary one if needed, which is released once f returns.
+func (mw *modelWidgets) peek(i int, f func(w Widget)) {
+	if w := mw.get(i); w != nil {
+		f(w)
+		return
+	}
+	w := mw.m.Widget(i, mw.recycled())
+	f(w)
+	mw.release(i, w)
+}
+
+// release releases the widget w of the property at index i, which can then
+// be recycled.
+func (mw *modelWidgets) release(i int, w Widget) {
+	mw.m.Release(i, w)
+	mw.free = append(mw.free, w)
+}
+
+func (mw *modelWidgets) recycled() Widget {
+	n := len(mw.free)
+	if n == 0 {
+		return nil
+	}
+	w := mw.free[n-1]
+	mw.free[n-1] = nil
+	mw.free = mw.free[:n-1]
+	return w
+}
+
+// endFrame releases the widgets which haven't been laid out during the frame,
+// and returns the indices of their properties.
+func (mw *modelWidgets) endFrame() []int {
+	var released []int
+	for i, lw := range mw.live {
+		if lw.frame != mw.frame {
+			mw.release(i, lw.w)
+			delete(mw.live, i)
+			released = append(released, i)
+		}
+	}
+	mw.frame++
+	return released
+}
+
+// SetModel sets the model providing the properties of the list, replacing the
+// ones added with Add. If m is nil, the list shows the added properties again.
+// Properties of a model don't have default values. The widgets of the
+// previous model are released.
+func (plist *List) SetModel(m Model) {
+	plist.states = nil
+	if plist.model != nil {
+		for i, lw := range plist.model.live {
+			plist.model.m.Release(i, lw.w)
+		}
+	}
+	if m == nil {
+		plist.model = nil
+		return
+	}
+	plist.model = &modelWidgets{m: m}
+	plist.list.Position = layout.Position{}
+}
+
+// len returns the number of properties.
+func (plist *List) len() int {
+	if plist.model != nil {
+		return plist.model.m.Len()
+	}
+	return len(plist.widgets)
+}
+
+// name returns the name of the property at index i.
+func (plist *List) name(i int) string {
+	if plist.model != nil {
+		return plist.model.m.Name(i)
+	}
+	return plist.names[i]
+}
+
+// desc returns the description of the property at index i.
+func (plist *List) desc(i int) string {
+	if plist.model != nil {
+		if d, ok := plist.model.m.(Describer); ok {
+			return d.Description(i)
+		}
+		return ""
+	}
+	return plist.descs[i]
+}
+
+// withWidget calls f with the widget of the property at index i, without
+// keeping it if it's created by the model.
+func (plist *List) withWidget(i int, f func(w Widget)) {
+	if plist.model != nil {
+		plist.model.peek(i, f)
+		return
+	}
+	f(plist.widgets[i])
+}
@@ -0,0 +1,203 @@
+package property
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"gioui.org/layout"
+)
+
+// intModel is a model of n integer properties, whose values are saved in
+// vals when their widgets are released.
+type intModel struct {
+	n        int
+	vals     map[int]int
+	created  int
+	recycled int
+	released int
+	names    int
+
+	// slices are the properties shown with a Slice.
+	slices map[int]*Slice[int]
+}
+
+func (m *intModel) Len() int { return m.n }
+
+func (m *intModel) Name(i int) string {
+	m.names++
+	return "p" + strconv.Itoa(i)
+}
+
+func (m *intModel) Widget(i int, recycled Widget) Widget {
+	if s, ok := m.slices[i]; ok {
+		return s
+	}
+	if p, ok := recycled.(*Int); ok {
+		m.recycled++
+		p.SetValue(m.vals[i])
+		return p
+	}
+	m.created++
+	return NewInt(m.vals[i])
+}
+
+func (m *intModel) Release(i int, w Widget) {
+	m.released++
+	if p, ok := w.(*Int); ok {
+		m.vals[i] = p.Value()
+	}
+}
+
+func TestListModel(t *testing.T) {
+	m := &intModel{n: 10000, vals: map[int]int{5: 42}}
+
+	ignored := NewInt(0)
+	plist := NewList()
+	plist.Add("ignored", ignored)
+	plist.SetModel(m)
+
+	frame := func() { layoutFrame(t, plist, nil) }
+
+	frame()
+	if got := plist.nrows(); got != m.n {
+		t.Fatalf("got %d rows, want %d", got, m.n)
+	}
+	visible := len(plist.model.live)
+	if visible == 0 || visible > 20 || m.created != visible {
+		t.Fatalf("created %d widgets for %d visible rows", m.created, visible)
+	}
+	if got := plist.model.get(5).(*Int).Value(); got != 42 {
+		t.Fatalf("p5 = %d, want 42", got)
+	}
+
+	// Rows of a model are never built, only the visible ones are named.
+	m.names = 0
+	frame()
+	if len(plist.rows) != 0 || len(plist.heights) > 2*visible {
+		t.Errorf("%d rows and %d heights for %d visible rows", len(plist.rows), len(plist.heights), visible)
+	}
+	if m.names > 10*visible {
+		t.Errorf("%d names for %d visible rows", m.names, visible)
+	}
+
+	// Scrolling recycles the widgets of the rows which aren't visible anymore.
+	for i := 1; i <= 10; i++ {
+		plist.SetScrollPosition(layout.Position{First: i * 100})
+		frame()
+	}
+	// Widgets are released at the end of the frame, after the ones of the
+	// newly visible rows have been created.
+	if m.created > 3*visible {
+		t.Errorf("created %d widgets, want at most %d", m.created, 3*visible)
+	}
+	if m.recycled == 0 {
+		t.Errorf("no widget has been recycled")
+	}
+	if got := len(plist.model.live); got > visible+1 {
+		t.Errorf("%d live widgets, want at most %d", got, visible+1)
+	}
+
+	if i := plist.index("p9999"); i != 9999 {
+		t.Errorf("index(p9999) = %d, want 9999", i)
+	}
+	if err := plist.ScrollTo(9999); err != nil {
+		t.Error(err)
+	}
+	if plist.Modified("p5") {
+		t.Errorf("model properties shouldn't be modified")
+	}
+
+	if err := plist.UnmarshalText([]byte("p7=3")); err != nil {
+		t.Error(err)
+	}
+	if got := m.vals[7]; got != 3 {
+		t.Errorf("p7 = %d in the model, want 3", got)
+	}
+	if txt := plist.text(); !strings.Contains(txt, "p5=42\n") || !strings.Contains(txt, "p7=3\n") {
+		t.Errorf("text doesn't contain p5=42 and p7=3")
+	}
+
+	// The properties added to the list are hidden by the model.
+	ignored.SetValue(1)
+	plist.SetDefaults()
+	if err := plist.ResetAll(); err != nil || ignored.Value() != 1 {
+		t.Errorf("ResetAll() = %v with a model, value = %d, want 1", err, ignored.Value())
+	}
+
+	plist.SetModel(nil)
+	frame()
+	if got := len(plist.rows); got != 1 {
+		t.Errorf("got %d rows without model, want 1", got)
+	}
+	if !plist.Modified("ignored") {
+		t.Errorf("defaults changed while the model was set")
+	}
+}
+
+func TestListModelExpander(t *testing.T) {
+	s := newIntSlice(1, 2, 3)
+	s.SetExpanded(true)
+	m := &intModel{n: 100, vals: map[int]int{}, slices: map[int]*Slice[int]{3: s}}
+	plist := NewList()
+	plist.SetModel(m)
+
+	frame := func() { layoutFrame(t, plist, nil) }
+
+	// The children of the expanded slice aren't shown: each property of a
+	// model is a single row, so rows don't move when widgets are recycled.
+	for _, first := range []int{0, 50, 0} {
+		plist.SetScrollPosition(layout.Position{First: first})
+		frame()
+		frame()
+		if got := plist.nrows(); got != m.n {
+			t.Fatalf("got %d rows, want %d", got, m.n)
+		}
+		if r := plist.row(4); r.prop != 4 || r.depth != 0 || r.name != "p4" {
+			t.Fatalf("row 4 = %+v, want property p4", r)
+		}
+		if got := plist.ScrollPosition().First; got != first {
+			t.Fatalf("first row = %d, want %d", got, first)
+		}
+	}
+	if plist.row(3).w != s || !s.Expanded() {
+		t.Errorf("slice widget not kept as is")
+	}
+}
+
+func TestListModelRelease(t *testing.T) {
+	m := &intModel{n: 1000, vals: map[int]int{}}
+	plist := NewList()
+	plist.SetModel(m)
+
+	frame := func() { layoutFrame(t, plist, nil) }
+
+	frame()
+	if err := plist.model.get(3).(*Int).paste("7"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Scroll away: the edited widget is released and recycled.
+	plist.SetScrollPosition(layout.Position{First: 500})
+	frame()
+	if m.released == 0 || m.vals[3] != 7 {
+		t.Fatalf("p3 = %d in the model after %d releases, want 7", m.vals[3], m.released)
+	}
+	frame()
+	if m.recycled == 0 {
+		t.Fatalf("no widget has been recycled")
+	}
+
+	plist.SetScrollPosition(layout.Position{First: 0})
+	frame()
+	if got := plist.model.get(3).(*Int).Value(); got != 7 {
+		t.Errorf("p3 = %d after scrolling back, want 7", got)
+	}
+
+	// Widgets still in use are released when the model is replaced.
+	plist.model.get(4).(*Int).SetValue(8)
+	plist.SetModel(nil)
+	if m.vals[4] != 8 {
+		t.Errorf("p4 = %d in the model after SetModel(nil), want 8", m.vals[4])
+	}
+}
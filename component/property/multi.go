@@ -0,0 +1,337 @@
+package property
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+)
+
+// MultiDropDown is a DropDown allowing to select multiple items. Clicking an
+// item, or pressing Enter when it's highlighted, toggles its selection and
+// keeps the menu opened. Selected items have a checkmark in the menu and are
+// shown as chips when the menu is closed, or summarized as "N selected" if the
+// chips don't fit.
+type MultiDropDown struct {
+	// Placeholder is the text shown when no item is selected.
+	Placeholder string
+
+	// Provider, if set, is called each time the menu is opened to refresh the
+	// list of items, as with SetItems.
+	Provider func() []Item
+
+	// FilterThreshold is the number of items above which the menu shows a
+	// filter box, see DropDown.
+	FilterThreshold int
+
+	// MenuMaxHeight is the maximum height of the menu, past which it scrolls.
+	MenuMaxHeight unit.Dp
+
+	// dd implements the menu, its Selected field is never set.
+	dd *DropDown
+}
+
+// NewMultiDropDown creates a MultiDropDown showing items. Initially no item is
+// selected.
+func NewMultiDropDown(items []Item) *MultiDropDown {
+	dd := NewDropDownItems(items)
+	dd.Selected = NoSelection
+	dd.multi = &multiSelection{checked: make([]bool, len(items))}
+	return &MultiDropDown{
+		FilterThreshold: dd.FilterThreshold,
+		MenuMaxHeight:   dd.MenuMaxHeight,
+		dd:              dd,
+	}
+}
+
+// configure passes the settings of m to its dropdown.
+func (m *MultiDropDown) configure() {
+	m.dd.Placeholder = m.Placeholder
+	m.dd.Provider = m.Provider
+	m.dd.FilterThreshold = m.FilterThreshold
+	m.dd.MenuMaxHeight = m.MenuMaxHeight
+}
+
+// Items returns the items shown by the dropdown.
+func (m *MultiDropDown) Items() []Item {
+	return m.dd.items
+}
+
+// SetItems replaces the items shown by the dropdown. The selection of the
+// options having the same labels as selected items is preserved.
+func (m *MultiDropDown) SetItems(items []Item) {
+	m.dd.SetItems(items)
+}
+
+func (m *MultiDropDown) Layout(th *material.Theme, pgtx, gtx C) D {
+	m.configure()
+	return m.dd.Layout(th, pgtx, gtx)
+}
+
+func (m *MultiDropDown) editing() bool {
+	return m.dd.editing()
+}
+
+func (m *MultiDropDown) setPlacement(p Placement) {
+	m.dd.setPlacement(p)
+}
+
+func (m *MultiDropDown) setStyle(s *ListStyle) {
+	m.dd.setStyle(s)
+}
+
+// SelectedIndices returns the indices of the selected items, in ascending
+// order.
+func (m *MultiDropDown) SelectedIndices() []int {
+	var sel []int
+	for i := range m.dd.items {
+		if m.IsSelected(i) {
+			sel = append(sel, i)
+		}
+	}
+	return sel
+}
+
+// SelectedItems returns the selected items.
+func (m *MultiDropDown) SelectedItems() []Item {
+	var items []Item
+	for _, i := range m.SelectedIndices() {
+		items = append(items, m.dd.items[i])
+	}
+	return items
+}
+
+// SetSelected selects the items at the given indices, and deselects the others.
+// Indices of non-selectable items are ignored.
+func (m *MultiDropDown) SetSelected(indices ...int) {
+	m.dd.multi.checked = make([]bool, len(m.dd.items))
+	for _, i := range indices {
+		if i >= 0 && i < len(m.dd.items) && m.dd.selectable(i) {
+			m.dd.multi.checked[i] = true
+		}
+	}
+}
+
+// String returns the labels of the selected items, separated by commas.
+// Labels containing commas or quotes, or surrounded by spaces, are quoted as
+// Go strings.
+func (m *MultiDropDown) String() string {
+	var labels []string
+	for _, item := range m.SelectedItems() {
+		labels = append(labels, quoteLabel(item.Label))
+	}
+	return strings.Join(labels, ", ")
+}
+
+// Set selects the items having the given comma-separated labels, in the
+// format returned by String, and deselects the others.
+func (m *MultiDropDown) Set(s string) error {
+	if m.Provider != nil {
+		m.SetItems(m.Provider())
+	}
+	labels, err := splitLabels(s)
+	if err != nil {
+		return err
+	}
+	var indices []int
+	for _, label := range labels {
+		i := m.dd.indexOf(label)
+		if i < 0 {
+			return fmt.Errorf("no item %q", label)
+		}
+		indices = append(indices, i)
+	}
+	m.SetSelected(indices...)
+	return nil
+}
+
+// quoteLabel returns label, quoted with strconv.Quote if it couldn't be told
+// apart from the other labels by splitLabels.
+func quoteLabel(label string) string {
+	if strings.ContainsAny(label, `,"`) || label != strings.TrimSpace(label) {
+		return strconv.Quote(label)
+	}
+	return label
+}
+
+// splitLabels returns the comma-separated labels of s, unquoting the quoted
+// ones. Empty unquoted labels are ignored.
+func splitLabels(s string) ([]string, error) {
+	var labels []string
+	for {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return labels, nil
+		}
+		if !strings.HasPrefix(s, `"`) {
+			var label string
+			label, s, _ = strings.Cut(s, ",")
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+			continue
+		}
+		q, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quoted label in %q", s)
+		}
+		label, _ := strconv.Unquote(q)
+		labels = append(labels, label)
+		s = strings.TrimSpace(s[len(q):])
+		if s != "" && s[0] != ',' {
+			return nil, fmt.Errorf("missing ',' after %s", q)
+		}
+		s = strings.TrimPrefix(s, ",")
+	}
+}
+
+// IsSelected reports whether the item at index i is selected.
+func (m *MultiDropDown) IsSelected(i int) bool {
+	return i >= 0 && i < len(m.dd.items) && i < len(m.dd.multi.checked) && m.dd.multi.checked[i] && m.dd.selectable(i)
+}
+
+// multiSelection holds the state specific to multi-selection dropdowns.
+type multiSelection struct {
+	// checked reports, for each item index, whether it's selected.
+	checked []bool
+}
+
+func (ms *multiSelection) toggle(i int) {
+	for len(ms.checked) <= i {
+		ms.checked = append(ms.checked, false)
+	}
+	ms.checked[i] = !ms.checked[i]
+}
+
+// remap preserves the selection by label, when items are replaced.
+func (ms *multiSelection) remap(old, items []Item) {
+	labels := make(map[string]bool)
+	for i := range old {
+		if i < len(ms.checked) && ms.checked[i] && old[i].Kind == ItemOption {
+			labels[old[i].Label] = true
+		}
+	}
+	ms.checked = make([]bool, len(items))
+	for i := range items {
+		ms.checked[i] = items[i].Kind == ItemOption && labels[items[i].Label]
+	}
+}
+
+// layoutCheck lays out a menu item, with a checkmark on its left if the item at
+// index idx is selected.
+func (ms *multiSelection) layoutCheck(gtx C, idx int, item component.MenuItemStyle) D {
+	const size = unit.Dp(16)
+	checked := idx < len(ms.checked) && ms.checked[idx]
+
+	// Shift the item contents to make room for the checkmark, which is
+	// drawn on top so that the whole item remains clickable.
+	left := &item.LabelInset.Left
+	if item.Icon != nil {
+		left = &item.IconInset.Left
+	}
+	x := *left
+	*left += size + x
+
+	dims := item.Layout(gtx)
+	if !checked {
+		return dims
+	}
+
+	sz := gtx.Dp(size)
+	off := image.Pt(gtx.Dp(x), (dims.Size.Y-sz)/2)
+	defer op.Offset(off).Push(gtx.Ops).Pop()
+	drawCheck(gtx, sz, item.Label.Color)
+	return dims
+}
+
+// drawCheck draws a checkmark in a square of side size.
+func drawCheck(gtx C, size int, col color.NRGBA) {
+	sz := float32(size)
+	var p clip.Path
+	p.Begin(gtx.Ops)
+	p.MoveTo(f32.Pt(sz*0.15, sz*0.5))
+	p.LineTo(f32.Pt(sz*0.4, sz*0.75))
+	p.LineTo(f32.Pt(sz*0.85, sz*0.25))
+	stroke := clip.Stroke{Path: p.End(), Width: float32(gtx.Dp(2))}
+	paint.FillShape(gtx.Ops, col, stroke.Op())
+}
+
+// layoutSummary lays out the selected items as chips or, if they don't fit,
+// as the number of selected items.
+func (ms *multiSelection) layoutSummary(s *ListStyle, gtx C, a *DropDown) D {
+	var labels []string
+	for i := range a.items {
+		if i < len(ms.checked) && ms.checked[i] && a.selectable(i) {
+			labels = append(labels, a.items[i].Label)
+		}
+	}
+
+	label := func(txt string) material.LabelStyle {
+		l := material.Label(s.Theme, s.TextSize, txt)
+		l.MaxLines = 1
+		l.Color = s.Theme.Fg
+		return l
+	}
+	if len(labels) == 0 {
+		l := label(a.Placeholder)
+		l.Color = s.Muted
+		return l.Layout(gtx)
+	}
+
+	// Record chips to check whether they all fit.
+	spacing := gtx.Dp(4)
+	var (
+		calls []op.CallOp
+		dims  []D
+		total int
+	)
+	cgtx := gtx
+	cgtx.Constraints.Min = image.Point{}
+	for _, txt := range labels {
+		macro := op.Record(gtx.Ops)
+		d := layoutChip(s, cgtx, label(txt))
+		calls = append(calls, macro.Stop())
+		dims = append(dims, d)
+		total += d.Size.X + spacing
+	}
+
+	if total-spacing > gtx.Constraints.Max.X {
+		return label(fmt.Sprintf("%d selected", len(labels))).Layout(gtx)
+	}
+
+	x := 0
+	for i, call := range calls {
+		off := image.Pt(x, (gtx.Constraints.Max.Y-dims[i].Size.Y)/2)
+		stack := op.Offset(off).Push(gtx.Ops)
+		call.Add(gtx.Ops)
+		stack.Pop()
+		x += dims[i].Size.X + spacing
+	}
+	return D{Size: image.Pt(x-spacing, gtx.Constraints.Max.Y)}
+}
+
+// layoutChip lays out a label on a rounded rectangle.
+func layoutChip(s *ListStyle, gtx C, label material.LabelStyle) D {
+	label.TextSize = s.TextSize * 0.9
+	return layout.Stack{}.Layout(gtx,
+		layout.Expanded(func(gtx C) D {
+			r := gtx.Dp(4)
+			rr := clip.UniformRRect(image.Rectangle{Max: gtx.Constraints.Min}, r)
+			paint.FillShape(gtx.Ops, s.Selected, rr.Op(gtx.Ops))
+			return D{Size: gtx.Constraints.Min}
+		}),
+		layout.Stacked(func(gtx C) D {
+			return s.Inset.Layout(gtx, label.Layout)
+		}),
+	)
+}
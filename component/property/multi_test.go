@@ -0,0 +1,114 @@
+package property
+
+import (
+	"testing"
+
+	"gioui.org/io/key"
+	"golang.org/x/exp/slices"
+)
+
+func TestMultiDropDown(t *testing.T) {
+	m := NewMultiDropDown([]Item{
+		{Label: "a"},
+		{Label: "b", Disabled: true},
+		{Label: "c"},
+		{Label: "d"},
+	})
+	if sel := m.SelectedIndices(); len(sel) != 0 {
+		t.Fatalf("SelectedIndices() = %v, want none", sel)
+	}
+
+	m.dd.openMenu()
+	m.dd.handleKey(press(key.NameReturn, 0))
+	m.dd.handleKey(press(key.NameDownArrow, 0))
+	m.dd.handleKey(press(key.NameReturn, 0))
+	if !m.dd.open {
+		t.Fatalf("menu should stay opened while toggling items")
+	}
+	if want := []int{0, 2}; !slices.Equal(m.SelectedIndices(), want) {
+		t.Fatalf("SelectedIndices() = %v, want %v", m.SelectedIndices(), want)
+	}
+	m.dd.handleKey(press(key.NameReturn, 0))
+	if want := []int{0}; !slices.Equal(m.SelectedIndices(), want) {
+		t.Fatalf("SelectedIndices() = %v, want %v", m.SelectedIndices(), want)
+	}
+
+	m.SetSelected(1, 3, 42)
+	if want := []int{3}; !slices.Equal(m.SelectedIndices(), want) {
+		t.Fatalf("SelectedIndices() = %v, want %v", m.SelectedIndices(), want)
+	}
+
+	m.SetItems([]Item{{Label: "d"}, {Label: "e"}})
+	if want := []int{0}; !slices.Equal(m.SelectedIndices(), want) {
+		t.Fatalf("SelectedIndices() = %v, want %v after SetItems", m.SelectedIndices(), want)
+	}
+	if items := m.SelectedItems(); len(items) != 1 || items[0].Label != "d" {
+		t.Fatalf("SelectedItems() = %v, want [d]", items)
+	}
+}
+
+func TestMultiDropDownString(t *testing.T) {
+	m := NewMultiDropDown([]Item{{Label: "a"}, {Label: "b"}, {Label: "c"}})
+	if err := m.Set("c, a"); err != nil {
+		t.Fatalf("Set() = %v", err)
+	}
+	if got, want := m.String(), "a, c"; got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+	if err := m.Set("a, z"); err == nil {
+		t.Fatalf("Set() should fail for unknown labels")
+	}
+	if got, want := m.String(), "a, c"; got != want {
+		t.Fatalf("String() = %q, want %q after failed Set", got, want)
+	}
+}
+
+func TestMultiDropDownQuoting(t *testing.T) {
+	m := NewMultiDropDown([]Item{{Label: "Foo, Inc."}, {Label: `say "hi"`}, {Label: "plain"}})
+	plist := NewList()
+	plist.Add("tags", m)
+
+	m.SetSelected(0, 1, 2)
+	want := `"Foo, Inc.", "say \"hi\"", plain`
+	if got := m.String(); got != want {
+		t.Fatalf("String() = %q, want %q", got, want)
+	}
+	m.SetSelected()
+	if err := m.Set(want); err != nil {
+		t.Fatalf("Set(String()) = %v", err)
+	}
+	if sel := m.SelectedIndices(); !slices.Equal(sel, []int{0, 1, 2}) {
+		t.Fatalf("SelectedIndices() = %v after Set, want [0 1 2]", sel)
+	}
+	if err := plist.ResetAll(); err != nil {
+		t.Fatalf("ResetAll() = %v", err)
+	}
+	if sel := m.SelectedIndices(); len(sel) != 0 {
+		t.Fatalf("SelectedIndices() = %v after ResetAll, want none", sel)
+	}
+
+	for _, s := range []string{`"Foo, Inc.`, `"plain" x`} {
+		if err := m.Set(s); err == nil {
+			t.Errorf("Set(%q) should fail", s)
+		}
+	}
+}
+
+func TestMultiDropDownSettings(t *testing.T) {
+	m := NewMultiDropDown(nil)
+	m.Placeholder = "none"
+	m.Provider = func() []Item { return []Item{{Label: "x"}, {Label: "y"}} }
+	if err := m.Set("y"); err != nil {
+		t.Fatalf("Set() = %v with a provider", err)
+	}
+	if want := []int{1}; !slices.Equal(m.SelectedIndices(), want) {
+		t.Fatalf("SelectedIndices() = %v, want %v", m.SelectedIndices(), want)
+	}
+
+	plist := NewList()
+	plist.Add("tags", m)
+	layoutFrame(t, plist, nil)
+	if m.dd.Placeholder != "none" || m.dd.Selected != NoSelection {
+		t.Errorf("dropdown not configured: placeholder %q, selected %d", m.dd.Placeholder, m.dd.Selected)
+	}
+}
@@ -0,0 +1,98 @@
+package property
+
+import (
+	"image"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/unit"
+)
+
+// Popup positions a widget, such as a menu, next to the property widget it
+// belongs to, on top of everything else. The popup is kept within the area of
+// its Placement or, by default, within the parent context (see Widget): it's
+// placed below the property if there's enough room, above otherwise, shifted
+// horizontally to fit, and its height is capped.
+type Popup struct {
+	// MaxHeight is the maximum height of the popup. If 0, the popup can take
+	// all the available height. Widgets taller than that should scroll.
+	MaxHeight unit.Dp
+
+	// MatchWidth forces the popup to be as wide as the property widget.
+	MatchWidth bool
+
+	// Placement is the placement of the property widget. If its bounds are
+	// empty, the widget is assumed to be at the origin of the parent context.
+	// If its area is empty, popups are kept within the parent context.
+	Placement Placement
+}
+
+// Layout lays out w as a popup of the property widget laid out with gtx, pgtx
+// being its parent context. w is laid out now but drawn on top of everything
+// else, with an op.DeferOp.
+func (p Popup) Layout(pgtx, gtx C, w layout.Widget) D {
+	anchor := p.Placement.Bounds
+	if anchor.Empty() {
+		anchor = image.Rectangle{Max: gtx.Constraints.Max}
+	}
+	parent := p.Placement.Area
+	if parent.Empty() {
+		parent = image.Rectangle{Max: pgtx.Constraints.Max}
+	}
+
+	below := parent.Max.Y - anchor.Max.Y
+	above := anchor.Min.Y - parent.Min.Y
+	maxh := max(0, max(below, above))
+	if p.MaxHeight != 0 {
+		maxh = min(maxh, gtx.Dp(p.MaxHeight))
+	}
+
+	gtx.Constraints.Min = image.Point{}
+	gtx.Constraints.Max = image.Pt(parent.Dx(), maxh)
+	if p.MatchWidth {
+		gtx.Constraints.Min.X = anchor.Dx()
+		gtx.Constraints.Max.X = anchor.Dx()
+	}
+
+	macro := op.Record(gtx.Ops)
+	dims := w(gtx)
+	call := macro.Stop()
+
+	pos := place(anchor, parent, dims.Size)
+
+	// Our ops are relative to the anchor.
+	macro = op.Record(gtx.Ops)
+	op.Offset(pos.Sub(anchor.Min)).Add(gtx.Ops)
+	call.Add(gtx.Ops)
+	op.Defer(gtx.Ops, macro.Stop())
+	return dims
+}
+
+// place returns the position, in the parent rectangle, of a popup of size sz
+// attached to the anchor rectangle. The popup opens below the anchor if it
+// fits, above otherwise. It's aligned on the left of the anchor, unless that
+// would make it overflow the parent.
+func place(anchor, parent image.Rectangle, sz image.Point) image.Point {
+	pos := image.Pt(anchor.Min.X, anchor.Max.Y)
+	if pos.Y+sz.Y > parent.Max.Y {
+		pos.Y = anchor.Min.Y - sz.Y
+	}
+	if pos.X+sz.X > parent.Max.X {
+		pos.X = parent.Max.X - sz.X
+	}
+	if pos.X < parent.Min.X {
+		pos.X = parent.Min.X
+	}
+	return pos
+}
+
+// Placement is the placement of a property widget laid out by a List, which
+// Popup uses to place popups next to the widget.
+type Placement struct {
+	// Bounds are the bounds of the widget, relative to the parent context.
+	Bounds image.Rectangle
+
+	// Area is the area where popups of the widget can be shown, relative to
+	// the parent context, see List.PopupArea.
+	Area image.Rectangle
+}
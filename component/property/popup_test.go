@@ -0,0 +1,62 @@
+package property
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPlace(t *testing.T) {
+	parent := image.Rect(0, 0, 400, 300)
+	tests := []struct {
+		name   string
+		anchor image.Rectangle
+		sz     image.Point
+		want   image.Point
+	}{
+		{"below", image.Rect(100, 0, 300, 30), image.Pt(200, 100), image.Pt(100, 30)},
+		{"above", image.Rect(100, 250, 300, 280), image.Pt(200, 100), image.Pt(100, 150)},
+		{"exactly fits below", image.Rect(100, 170, 300, 200), image.Pt(200, 100), image.Pt(100, 200)},
+		{"shift left", image.Rect(300, 0, 400, 30), image.Pt(200, 100), image.Pt(200, 30)},
+		{"wider than parent", image.Rect(300, 0, 400, 30), image.Pt(500, 100), image.Pt(0, 30)},
+	}
+	for _, tt := range tests {
+		if got := place(tt.anchor, parent, tt.sz); got != tt.want {
+			t.Errorf("%s: place() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// The area of a list showing popups over the whole window starts at
+	// negative coordinates.
+	window := image.Rect(-50, -100, 450, 400)
+	if got := place(image.Rect(100, 270, 300, 300), window, image.Pt(200, 100)); got != image.Pt(100, 300) {
+		t.Errorf("window: place() = %v, want %v", got, image.Pt(100, 300))
+	}
+}
+
+func TestListPlacement(t *testing.T) {
+	dd := NewDropDown([]string{"a", "b"})
+	plist := NewList()
+	plist.Add("first", NewInt(0))
+	plist.Add("dropdown", dd)
+
+	// The placement must survive contexts without queue, such as disabled
+	// ones.
+	layoutFrame(t, plist, nil)
+
+	h := C{}.Dp(plist.PropertyHeight)
+	if b := dd.placement.Bounds; b.Min.Y != h || b.Dy() != h || b.Max.X > 300 {
+		t.Errorf("bounds = %v, want the second row", b)
+	}
+	if a := dd.placement.Area; a != image.Rect(0, 0, 300, 300) {
+		t.Errorf("area = %v, want the list", a)
+	}
+	if dd.style == nil {
+		t.Errorf("style not set")
+	}
+
+	plist.PopupArea = image.Rect(-100, -100, 800, 600)
+	layoutFrame(t, plist, nil)
+	if a := dd.placement.Area; a != plist.PopupArea {
+		t.Errorf("area = %v, want %v", a, plist.PopupArea)
+	}
+}
@@ -0,0 +1,91 @@
+package property
+
+import (
+	"fmt"
+
+	"gioui.org/layout"
+	"gioui.org/widget/material"
+)
+
+// ScrollTo scrolls the list at next frame so that the property at index i,
+// in the order properties have been added, is the first visible row.
+func (plist *List) ScrollTo(i int) error {
+	if n := plist.len(); i < 0 || i >= n {
+		return fmt.Errorf("property index %d out of range [0, %d)", i, n)
+	}
+	if plist.model != nil {
+		// Each property of a model is a row.
+		plist.SetScrollPosition(layout.Position{First: i, BeforeEnd: true})
+		return nil
+	}
+	plist.scrollTo = func(r row) bool { return r.prop == i }
+	return nil
+}
+
+// ScrollToName scrolls the list at next frame so that the row of the property
+// at path is the first visible row. The path of a child property is made of
+// the names of its ancestors, such as "points[0].x". An error is returned if
+// there's no such row, which is also the case of a child property whose parent
+// is collapsed.
+func (plist *List) ScrollToName(path string) error {
+	if plist.model != nil {
+		i := plist.index(path)
+		if i < 0 {
+			return fmt.Errorf("no property %q", path)
+		}
+		return plist.ScrollTo(i)
+	}
+	found := plist.index(path) >= 0
+	for _, r := range plist.rows {
+		found = found || r.path == path
+	}
+	if !found {
+		return fmt.Errorf("no property %q", path)
+	}
+	plist.scrollTo = func(r row) bool { return r.path == path }
+	return nil
+}
+
+// ScrollPosition returns the scroll position of the list, which can be saved
+// and later restored with SetScrollPosition.
+func (plist *List) ScrollPosition() layout.Position {
+	return plist.list.Position
+}
+
+// SetScrollPosition restores a scroll position returned by ScrollPosition.
+func (plist *List) SetScrollPosition(pos layout.Position) {
+	plist.list.Position = pos
+	plist.scrollTo = nil
+}
+
+// scroll performs the scrolling requested by ScrollTo or ScrollToName, once
+// the rows are built.
+func (plist *List) scroll() {
+	if plist.scrollTo == nil {
+		return
+	}
+	for i, r := range plist.rows {
+		if plist.scrollTo(r) {
+			plist.list.Position = layout.Position{First: i, BeforeEnd: true}
+			break
+		}
+	}
+	plist.scrollTo = nil
+}
+
+// rowsWidth returns the width of the rows, which don't overlap the scrollbar.
+func (plist *List) rowsWidth(gtx C) int {
+	if !plist.Scrollbar {
+		return gtx.Constraints.Max.X
+	}
+	bar := material.Scrollbar(plist.style.Theme, &plist.list.Scrollbar)
+	return max(0, gtx.Constraints.Max.X-gtx.Dp(bar.Width()))
+}
+
+// layoutRows lays out the rows with w and, if enabled, the scrollbar.
+func (plist *List) layoutRows(th *material.Theme, gtx C, w layout.ListElement) D {
+	if !plist.Scrollbar {
+		return plist.list.List.Layout(gtx, plist.nrows(), w)
+	}
+	return material.List(th, &plist.list).Layout(gtx, plist.nrows(), w)
+}
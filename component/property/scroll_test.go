@@ -0,0 +1,59 @@
+package property
+
+import (
+	"testing"
+
+	"gioui.org/layout"
+)
+
+func TestListScroll(t *testing.T) {
+	pts := newIntSlice(4, 5)
+	pts.SetExpanded(true)
+
+	plist := NewList()
+	plist.Add("a", NewInt(0))
+	plist.Add("slice", pts)
+	plist.Add("b", NewInt(1))
+
+	first := func() int {
+		plist.buildRows()
+		plist.scroll()
+		return plist.ScrollPosition().First
+	}
+
+	if err := plist.ScrollTo(2); err != nil {
+		t.Fatal(err)
+	}
+	if got := first(); got != 4 {
+		t.Errorf("ScrollTo(2): first row = %d, want 4", got)
+	}
+	if err := plist.ScrollTo(3); err == nil {
+		t.Errorf("ScrollTo(3) should fail")
+	}
+
+	if err := plist.ScrollToName("slice[1]"); err != nil {
+		t.Fatal(err)
+	}
+	if got := first(); got != 3 {
+		t.Errorf("ScrollToName(slice[1]): first row = %d, want 3", got)
+	}
+	if err := plist.ScrollToName("slice[2]"); err == nil {
+		t.Errorf("ScrollToName(slice[2]) should fail")
+	}
+	pts.SetExpanded(false)
+	plist.buildRows()
+	if err := plist.ScrollToName("slice[0]"); err == nil {
+		t.Errorf("ScrollToName(slice[0]) should fail while collapsed")
+	}
+	pts.SetExpanded(true)
+
+	saved := plist.ScrollPosition()
+	plist.SetScrollPosition(layout.Position{})
+	if got := first(); got != 0 {
+		t.Errorf("first row = %d after reset, want 0", got)
+	}
+	plist.SetScrollPosition(saved)
+	if got := first(); got != 3 {
+		t.Errorf("first row = %d after restore, want 3", got)
+	}
+}
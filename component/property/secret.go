@@ -0,0 +1,182 @@
+package property
+
+import (
+	"image"
+	"image/color"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+)
+
+const (
+	// secretMask is the rune shown in place of each character of a secret.
+	secretMask = '•'
+
+	// redacted is what a Secret returns from String, whatever its value.
+	redacted = "********"
+)
+
+// Secret is a Text property holding a sensitive string value, such as a
+// password or an API token. Its characters are masked, unless the user
+// reveals them by clicking the eye button on the right of the property.
+//
+// A Secret never exposes its value through String, so it doesn't leak via
+// exports or copy operations, and a List doesn't keep it as a default value:
+// secrets are never reset. The only way to read it is with Value.
+type Secret struct {
+	*Text
+
+	toggle   widget.Clickable
+	revealed bool
+}
+
+// NewSecret creates a Secret property holding val.
+func NewSecret(val string) *Secret {
+	s := &Secret{Text: NewText(newSecretval(val), "")}
+	s.editor.Mask = secretMask
+	return s
+}
+
+// Value returns the secret value, in clear.
+func (s *Secret) Value() string {
+	return s.value().(*secretval).plainText()
+}
+
+// SetValue sets the secret value.
+func (s *Secret) SetValue(val string) {
+	sv := s.value().(*secretval)
+	sv.Set(val)
+	s.setValue(sv)
+}
+
+// String returns a redacted representation of the secret.
+func (s *Secret) String() string {
+	return redacted
+}
+
+func (s *Secret) plainText() string {
+	return s.Value()
+}
+
+// Clear zeroes the buffer holding the secret value and empties the editor.
+func (s *Secret) Clear() {
+	sv := s.value().(*secretval)
+	sv.zero()
+	s.setValue(sv)
+}
+
+// Revealed reports whether the secret is currently shown in clear.
+func (s *Secret) Revealed() bool {
+	return s.revealed
+}
+
+// SetRevealed shows the secret in clear if reveal is true, masks it otherwise.
+func (s *Secret) SetRevealed(reveal bool) {
+	s.revealed = reveal
+}
+
+func (s *Secret) Layout(th *material.Theme, pgtx, gtx C) D {
+	for s.toggle.Clicked() {
+		s.revealed = !s.revealed
+	}
+	if s.revealed {
+		s.editor.Mask = 0
+	} else {
+		s.editor.Mask = secretMask
+	}
+
+	size := gtx.Constraints.Max
+	wbtn := min(size.X, size.Y)
+	{
+		gtx := gtx
+		gtx.Constraints = layout.Exact(image.Pt(size.X-wbtn, size.Y))
+		s.Text.Layout(th, pgtx, gtx)
+	}
+
+	// Never let the editor hold a selection, this prevents the secret from
+	// being copied to the clipboard.
+	if s.editor.SelectionLen() != 0 {
+		_, end := s.editor.Selection()
+		s.editor.SetCaret(end, end)
+	}
+
+	// Draw the eye button.
+	off := op.Offset(image.Pt(size.X-wbtn, 0)).Push(gtx.Ops)
+	gtx.Constraints = layout.Exact(image.Pt(wbtn, size.Y))
+	st := s.listStyle(th)
+	s.toggle.Layout(gtx, func(gtx C) D {
+		paint.FillShape(gtx.Ops, st.Editable, clip.Rect{Max: gtx.Constraints.Max}.Op())
+		col := st.Muted
+		if s.toggle.Hovered() {
+			col = th.Fg
+		}
+		drawEye(gtx, col, !s.revealed)
+		return D{Size: gtx.Constraints.Max}
+	})
+	off.Pop()
+
+	return D{Size: size}
+}
+
+// drawEye draws an eye at the center of the available space, crossed out if
+// slashed is true.
+func drawEye(gtx C, col color.NRGBA, slashed bool) {
+	sz := layout.FPt(gtx.Constraints.Max)
+	c := sz.Mul(0.5)
+	w := min(sz.X, sz.Y) * 0.6
+	h := w / 2
+	stroke := float32(gtx.Dp(1.5))
+
+	var p clip.Path
+	p.Begin(gtx.Ops)
+	p.MoveTo(f32.Pt(c.X-w/2, c.Y))
+	p.QuadTo(f32.Pt(c.X, c.Y-h), f32.Pt(c.X+w/2, c.Y))
+	p.QuadTo(f32.Pt(c.X, c.Y+h), f32.Pt(c.X-w/2, c.Y))
+	p.Close()
+	paint.FillShape(gtx.Ops, col, clip.Stroke{Path: p.End(), Width: stroke}.Op())
+
+	r := int(h / 4)
+	ic := image.Pt(int(c.X), int(c.Y))
+	pupil := clip.Ellipse{Min: ic.Sub(image.Pt(r, r)), Max: ic.Add(image.Pt(r, r))}
+	paint.FillShape(gtx.Ops, col, pupil.Op(gtx.Ops))
+
+	if slashed {
+		p.Begin(gtx.Ops)
+		p.MoveTo(f32.Pt(c.X-w/2, c.Y+h/2))
+		p.LineTo(f32.Pt(c.X+w/2, c.Y-h/2))
+		paint.FillShape(gtx.Ops, col, clip.Stroke{Path: p.End(), Width: stroke}.Op())
+	}
+}
+
+// secretval holds a secret in a byte slice, which can be zeroed.
+type secretval struct {
+	buf []byte
+}
+
+func newSecretval(s string) *secretval {
+	sv := &secretval{}
+	sv.Set(s)
+	return sv
+}
+
+func (s *secretval) Set(str string) error {
+	s.zero()
+	s.buf = append(s.buf[:0], str...)
+	return nil
+}
+
+func (s *secretval) zero() {
+	for i := range s.buf {
+		s.buf[i] = 0
+	}
+	s.buf = s.buf[:0]
+}
+
+func (s *secretval) String() string { return redacted }
+
+func (s *secretval) plainText() string { return string(s.buf) }
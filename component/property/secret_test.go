@@ -0,0 +1,54 @@
+package property
+
+import "testing"
+
+func TestSecret(t *testing.T) {
+	s := NewSecret("hunter2")
+	if got := s.Value(); got != "hunter2" {
+		t.Errorf("Value() = %q, want %q", got, "hunter2")
+	}
+	if got := s.String(); got != redacted {
+		t.Errorf("String() = %q, want %q", got, redacted)
+	}
+	if got := s.val.String(); got != redacted {
+		t.Errorf("val.String() = %q, want %q", got, redacted)
+	}
+
+	buf := s.value().(*secretval).buf[:7]
+	s.Clear()
+	for i, b := range buf {
+		if b != 0 {
+			t.Fatalf("buf[%d] = %q after Clear, want 0", i, b)
+		}
+	}
+	if got := s.Value(); got != "" {
+		t.Errorf("Value() = %q after Clear, want empty", got)
+	}
+	if got := s.editor.Text(); got != "" {
+		t.Errorf("editor text = %q after Clear, want empty", got)
+	}
+}
+
+func TestSecretNoDefault(t *testing.T) {
+	s := NewSecret("hunter2")
+	plist := NewList()
+	plist.Add("secret", s)
+	if plist.defaults[0] != "" {
+		t.Fatalf("default = %q, want none", plist.defaults[0])
+	}
+
+	s.SetValue("changed")
+	plist.SetDefaults()
+	if plist.defaults[0] != "" {
+		t.Fatalf("default = %q after SetDefaults, want none", plist.defaults[0])
+	}
+	if plist.Modified("secret") {
+		t.Errorf("secret reported as modified")
+	}
+	if err := plist.ResetAll(); err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Value(); got != "changed" {
+		t.Errorf("Value() = %q after ResetAll, want %q", got, "changed")
+	}
+}
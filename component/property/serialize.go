@@ -0,0 +1,256 @@
+package property
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors is returned when applying a document to a List fails for some
+// properties. It maps property names, or line numbers for malformed lines, to
+// the errors encountered.
+type Errors map[string]error
+
+func (e Errors) Error() string {
+	keys := make([]string, 0, len(e))
+	for k := range e {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		fmt.Fprintf(&sb, "%s: %v", k, e[k])
+	}
+	return sb.String()
+}
+
+// exported returns the indices of the properties which are exported by
+// MarshalText and MarshalJSON: those implementing Stringer, except the ones
+// whose string isn't their value, such as secrets.
+func (plist *List) exported() []int {
+	var indices []int
+	for i, n := 0, plist.len(); i < n; i++ {
+		plist.withWidget(i, func(w Widget) {
+			_, ok := w.(Stringer)
+			if _, plain := w.(plainTexter); ok && !plain {
+				indices = append(indices, i)
+			}
+		})
+	}
+	return indices
+}
+
+// set sets the value of the property with the given name, recording errors in
+// errs. If user is set, the value is pasted by the user and read-only
+// properties are left unchanged.
+func (plist *List) set(errs Errors, name, val string, user bool) {
+	i := plist.index(name)
+	if i < 0 {
+		errs[name] = fmt.Errorf("unknown property")
+		return
+	}
+	plist.withWidget(i, func(w Widget) {
+		if user && !canEdit(w) {
+			return
+		}
+		s, ok := w.(Stringer)
+		if !ok {
+			errs[name] = fmt.Errorf("property can't be set from text")
+			return
+		}
+		if err := s.Set(val); err != nil {
+			errs[name] = err
+		}
+	})
+}
+
+// value returns the string of the exported property at index i.
+func (plist *List) value(i int) string {
+	var s string
+	plist.withWidget(i, func(w Widget) { s = w.(Stringer).String() })
+	return s
+}
+
+func (plist *List) text() string {
+	var sb strings.Builder
+	for _, i := range plist.exported() {
+		name := quoteText(plist.name(i), true)
+		val := quoteText(plist.value(i), false)
+		fmt.Fprintf(&sb, "%s=%s\n", name, val)
+	}
+	return sb.String()
+}
+
+// quoteText returns s, quoted with strconv.Quote if it can't be read back as
+// is from a line of the text document: if it spans several lines or starts
+// with a quote or, for a name, if it contains '=', starts with '#' or is
+// surrounded by spaces.
+func quoteText(s string, name bool) string {
+	quote := strings.ContainsAny(s, "\n\r") || strings.HasPrefix(s, `"`)
+	if name {
+		quote = quote || strings.Contains(s, "=") || strings.HasPrefix(s, "#") || s != strings.TrimSpace(s)
+	}
+	if quote {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// cutLine returns the name and the value of a name=value line of the text
+// document, unquoting them if needed.
+func cutLine(line string) (name, val string, err error) {
+	rest := strings.TrimLeft(line, " \t")
+	if strings.HasPrefix(rest, `"`) {
+		q, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid quoted name in %q", line)
+		}
+		name, _ = strconv.Unquote(q)
+		rest = strings.TrimLeft(rest[len(q):], " \t")
+		if !strings.HasPrefix(rest, "=") {
+			return "", "", fmt.Errorf("missing '=' in %q", line)
+		}
+		val = rest[1:]
+	} else {
+		var ok bool
+		name, val, ok = strings.Cut(line, "=")
+		if !ok {
+			return "", "", fmt.Errorf("missing '=' in %q", line)
+		}
+		name = strings.TrimSpace(name)
+	}
+	if strings.HasPrefix(val, `"`) {
+		if val, err = strconv.Unquote(val); err != nil {
+			return "", "", fmt.Errorf("invalid quoted value in %q", line)
+		}
+	}
+	return name, val, nil
+}
+
+// MarshalText exports the property values as a text document with one
+// name=value line per property, in list order. Names and values which would
+// break the line, such as multi-line values, are quoted as Go strings.
+// Properties that don't implement Stringer are omitted, as well as secrets.
+func (plist *List) MarshalText() ([]byte, error) {
+	return []byte(plist.text()), nil
+}
+
+// UnmarshalText sets the property values from a text document in the format
+// returned by MarshalText. Empty lines and lines starting with '#' are
+// ignored, names and values starting with a quote are unquoted. All valid
+// values are set, the other ones are reported in the returned Errors.
+func (plist *List) UnmarshalText(text []byte) error {
+	return plist.unmarshalText(text, false)
+}
+
+// unmarshalText implements UnmarshalText, user being set when the text is
+// pasted by the user, see set.
+func (plist *List) unmarshalText(text []byte, user bool) error {
+	errs := make(Errors)
+	for n, line := range strings.Split(string(text), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, val, err := cutLine(line)
+		if err != nil {
+			errs[fmt.Sprintf("line %d", n+1)] = err
+			continue
+		}
+		plist.set(errs, name, val, user)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// MarshalJSON exports the property values as a JSON object mapping property
+// names to their values as strings, in list order. Properties that don't
+// implement Stringer are omitted, as well as secrets.
+func (plist *List) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for n, i := range plist.exported() {
+		if n > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(plist.name(i))
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(plist.value(i))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON sets the property values from a JSON object in the format
+// returned by MarshalJSON. Values can also be JSON numbers or booleans. All
+// valid values are set, the other ones are reported in the returned Errors.
+func (plist *List) UnmarshalJSON(data []byte) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+
+	// Apply values in list order, then the unknown ones.
+	names := make([]string, 0, len(doc))
+	for name := range doc {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		oi, oj := plist.order(names[i]), plist.order(names[j])
+		if oi != oj {
+			return oi < oj
+		}
+		return names[i] < names[j]
+	})
+
+	errs := make(Errors)
+	for _, name := range names {
+		raw := doc[name]
+		var val string
+		if err := json.Unmarshal(raw, &val); err != nil {
+			var v any
+			if err := json.Unmarshal(raw, &v); err != nil {
+				errs[name] = err
+				continue
+			}
+			switch v.(type) {
+			case float64, bool:
+				val = string(raw)
+			default:
+				errs[name] = fmt.Errorf("unsupported value %s", raw)
+				continue
+			}
+		}
+		plist.set(errs, name, val, false)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// order returns the position of the property with the given name in the list,
+// or the number of properties if there's none.
+func (plist *List) order(name string) int {
+	if i := plist.index(name); i >= 0 {
+		return i
+	}
+	return plist.len()
+}
@@ -0,0 +1,131 @@
+package property
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func newSerializeList() (*List, *Int, *String, *DropDown) {
+	i := NewInt(1)
+	str := NewString("a=b")
+	dd := NewDropDown([]string{"x", "y"})
+
+	plist := NewList()
+	plist.Add("int", i)
+	plist.Add("string", str)
+	plist.Add("secret", NewSecret("s3cr3t"))
+	plist.Add("dropdown", dd)
+	plist.Add("live", NewLive(func() string { return "live" }))
+	return plist, i, str, dd
+}
+
+func TestListText(t *testing.T) {
+	plist, i, str, dd := newSerializeList()
+
+	doc, _ := plist.MarshalText()
+	if want := "int=1\nstring=a=b\ndropdown=x\n"; string(doc) != want {
+		t.Fatalf("MarshalText() = %q, want %q", doc, want)
+	}
+
+	i.SetValue(2)
+	str.SetValue("c")
+	dd.Selected = 1
+	if err := plist.UnmarshalText(append([]byte("# preset\n\n"), doc...)); err != nil {
+		t.Fatalf("UnmarshalText() = %v", err)
+	}
+	if i.Value() != 1 || str.Value() != "a=b" || dd.String() != "x" {
+		t.Fatalf("values not set: %d, %q, %q", i.Value(), str.Value(), dd.String())
+	}
+
+	err := plist.UnmarshalText([]byte("int=foo\r\ndropdown=y\r\nunknown=1\r\nlive=2\r\nbad\r\n"))
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("UnmarshalText() = %v, want Errors", err)
+	}
+	for _, k := range []string{"int", "unknown", "live", "line 5"} {
+		if errs[k] == nil {
+			t.Errorf("missing error for %q in %v", k, errs)
+		}
+	}
+	if len(errs) != 4 {
+		t.Errorf("got %d errors, want 4: %v", len(errs), errs)
+	}
+	if i.Value() != 1 || dd.String() != "y" {
+		t.Fatalf("valid values should be set despite errors")
+	}
+}
+
+func TestListJSON(t *testing.T) {
+	plist, i, str, dd := newSerializeList()
+
+	doc, err := json.Marshal(plist)
+	if err != nil {
+		t.Fatalf("MarshalJSON() = %v", err)
+	}
+	if want := `{"int":"1","string":"a=b","dropdown":"x"}`; string(doc) != want {
+		t.Fatalf("MarshalJSON() = %s, want %s", doc, want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"int":42,"string":"c","dropdown":"y"}`), plist); err != nil {
+		t.Fatalf("UnmarshalJSON() = %v", err)
+	}
+	if i.Value() != 42 || str.Value() != "c" || dd.String() != "y" {
+		t.Fatalf("values not set: %d, %q, %q", i.Value(), str.Value(), dd.String())
+	}
+
+	err = json.Unmarshal([]byte(`{"int":[1],"dropdown":"z","string":"d"}`), plist)
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 || errs["int"] == nil || errs["dropdown"] == nil {
+		t.Fatalf("UnmarshalJSON() = %v, want errors for int and dropdown", err)
+	}
+	if str.Value() != "d" {
+		t.Fatalf("valid values should be set despite errors")
+	}
+}
+
+func TestListTextQuoting(t *testing.T) {
+	tests := []struct {
+		name, val string
+		line      string
+	}{
+		{"plain", "a=b", "plain=a=b"},
+		{"multi", "line 1\nline 2", `multi="line 1\nline 2"`},
+		{"cr", "a\r", `cr="a\r"`},
+		{"quoted", `"a"`, `quoted="\"a\""`},
+		{"a=b", "c", `"a=b"=c`},
+		{"#x", "y", `"#x"=y`},
+		{" pad ", "z", `" pad "=z`},
+		{"new\nline", "v", `"new\nline"=v`},
+	}
+	plist := NewList()
+	strs := make([]*String, len(tests))
+	var want string
+	for i, tt := range tests {
+		strs[i] = NewString(tt.val)
+		plist.Add(tt.name, strs[i])
+		want += tt.line + "\n"
+	}
+
+	doc, _ := plist.MarshalText()
+	if string(doc) != want {
+		t.Fatalf("MarshalText() = %q, want %q", doc, want)
+	}
+	for _, s := range strs {
+		s.SetValue("")
+	}
+	if err := plist.UnmarshalText(doc); err != nil {
+		t.Fatalf("UnmarshalText() = %v", err)
+	}
+	for i, tt := range tests {
+		if got := strs[i].Value(); got != tt.val {
+			t.Errorf("%q = %q, want %q", tt.name, got, tt.val)
+		}
+	}
+
+	err := plist.UnmarshalText([]byte("\"plain=x\n\"multi\" y\nmulti=\"unterminated\n"))
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 3 {
+		t.Fatalf("UnmarshalText() = %v, want errors for 3 lines", err)
+	}
+}
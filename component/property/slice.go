@@ -0,0 +1,278 @@
+package property
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strconv"
+
+	"gioui.org/gesture"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/text"
+	"gioui.org/widget"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+)
+
+// Value is implemented by property widgets holding a value of type T, such as
+// Int, String or TextValue.
+type Value[T any] interface {
+	Widget
+	Value() T
+	SetValue(val T)
+}
+
+// Slice is a property holding a list of values. Each element is shown as a
+// child row, edited with the property created by the element factory. When
+// editable, elements can be appended with the '+' button, removed with the
+// button on the right of their names and reordered by dragging the handle on
+// their left.
+type Slice[T any] struct {
+	Editable bool
+
+	newElem  func(T) Value[T]
+	elems    []*sliceElem[T]
+	expanded bool
+	add      widget.Clickable
+	listed
+}
+
+type sliceElem[T any] struct {
+	s   *Slice[T]
+	w   Value[T]
+	idx int
+
+	// listed is given to the label of the element.
+	listed
+
+	del   widget.Clickable
+	drag  gesture.Drag
+	dragY float32
+}
+
+// NewSlice creates a Slice property holding vals, using newElem to create the
+// property of each element.
+func NewSlice[T any](vals []T, newElem func(T) Value[T]) *Slice[T] {
+	s := &Slice[T]{Editable: true, newElem: newElem}
+	s.SetValue(vals)
+	return s
+}
+
+// Value returns the current values of the elements.
+func (s *Slice[T]) Value() []T {
+	vals := make([]T, len(s.elems))
+	for i, e := range s.elems {
+		vals[i] = e.w.Value()
+	}
+	return vals
+}
+
+// SetValue replaces all elements with vals.
+func (s *Slice[T]) SetValue(vals []T) {
+	s.elems = s.elems[:0]
+	for _, v := range vals {
+		s.append(v)
+	}
+}
+
+// Len returns the number of elements.
+func (s *Slice[T]) Len() int {
+	return len(s.elems)
+}
+
+func (s *Slice[T]) append(val T) {
+	s.elems = append(s.elems, &sliceElem[T]{s: s, w: s.newElem(val), idx: len(s.elems)})
+}
+
+func (s *Slice[T]) remove(i int) {
+	s.elems = append(s.elems[:i], s.elems[i+1:]...)
+	s.reindex()
+}
+
+// move moves the element at index i to index j.
+func (s *Slice[T]) move(i, j int) {
+	e := s.elems[i]
+	s.elems = append(s.elems[:i], s.elems[i+1:]...)
+	s.elems = append(s.elems[:j], append([]*sliceElem[T]{e}, s.elems[j:]...)...)
+	s.reindex()
+}
+
+func (s *Slice[T]) reindex() {
+	for i, e := range s.elems {
+		e.idx = i
+	}
+}
+
+func (s *Slice[T]) Expanded() bool {
+	return s.expanded
+}
+
+func (s *Slice[T]) SetExpanded(expanded bool) {
+	s.expanded = expanded
+}
+
+func (s *Slice[T]) Children() []Child {
+	children := make([]Child, len(s.elems))
+	for i, e := range s.elems {
+		children[i] = Child{
+			Name:   "[" + strconv.Itoa(i) + "]",
+			Label:  (*sliceLabel[T])(e),
+			Widget: e.w,
+		}
+	}
+	return children
+}
+
+func (s *Slice[T]) Layout(th *material.Theme, pgtx, gtx C) D {
+	for s.add.Clicked() {
+		if s.Editable {
+			var zero T
+			s.append(zero)
+			s.expanded = true
+		}
+	}
+
+	st := s.listStyle(th)
+	return layoutSummary(st, gtx, len(s.elems), st.ReadOnly, s.Editable, &s.add)
+}
+
+// layoutSummary lays out the value of a collection property, made of its
+// number of items and, if editable, a button to add an item.
+func layoutSummary(s *ListStyle, gtx C, n int, bg color.NRGBA, editable bool, add *widget.Clickable) D {
+	paint.FillShape(gtx.Ops, bg, clip.Rect{Max: gtx.Constraints.Max}.Op())
+
+	summary := fmt.Sprintf("[%d items]", n)
+	if n == 1 {
+		summary = "[1 item]"
+	}
+	label := material.Label(s.Theme, s.TextSize, summary)
+	label.MaxLines = 1
+	label.Alignment = text.Start
+	label.Color = s.Muted
+
+	return s.focusBorder(false).Layout(gtx, func(gtx C) D {
+		gtx.Constraints.Min = gtx.Constraints.Max
+		return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+			layout.Flexed(1, func(gtx C) D {
+				return s.Inset.Layout(gtx, label.Layout)
+			}),
+			layout.Rigid(func(gtx C) D {
+				if !editable {
+					return D{}
+				}
+				return layoutSquareButton(s, gtx, add, "+")
+			}),
+		)
+	})
+}
+
+// sliceLabel lays out the name column of an element: a drag handle, the index
+// and a button removing the element.
+type sliceLabel[T any] sliceElem[T]
+
+func (l *sliceLabel[T]) Layout(th *material.Theme, pgtx, gtx C) D {
+	e := (*sliceElem[T])(l)
+	s := e.s
+	st := e.listStyle(th)
+	h := gtx.Constraints.Max.Y
+
+	for e.del.Clicked() {
+		if s.Editable {
+			s.remove(e.idx)
+			return D{Size: gtx.Constraints.Max}
+		}
+	}
+
+	// Only consider the last drag event of the frame, later events would be
+	// relative to the position of the element before it moved.
+	var drag *pointer.Event
+	for _, ev := range e.drag.Events(gtx.Metric, gtx, gesture.Vertical) {
+		ev := ev
+		switch ev.Type {
+		case pointer.Press:
+			e.dragY = ev.Position.Y
+		case pointer.Drag:
+			drag = &ev
+		}
+	}
+	if drag != nil && s.Editable {
+		// Swap the element with its neighbour once the pointer went past
+		// half of it. The pointer position is relative to the element, which
+		// moves along.
+		dy := drag.Position.Y - e.dragY
+		switch {
+		case dy > float32(h)/2 && e.idx < len(s.elems)-1:
+			s.move(e.idx, e.idx+1)
+		case dy < -float32(h)/2 && e.idx > 0:
+			s.move(e.idx, e.idx-1)
+		}
+	}
+
+	label := material.Label(th, st.TextSize, "["+strconv.Itoa(e.idx)+"]")
+	label.MaxLines = 1
+	label.Font = st.NameFont
+	label.Alignment = text.Start
+
+	return layout.Flex{Alignment: layout.Middle}.Layout(gtx,
+		layout.Rigid(func(gtx C) D {
+			if !s.Editable {
+				return D{}
+			}
+			gtx.Constraints = layout.Exact(image.Pt(h, h))
+			defer clip.Rect{Max: gtx.Constraints.Max}.Push(gtx.Ops).Pop()
+			pointer.CursorGrab.Add(gtx.Ops)
+			e.drag.Add(gtx.Ops)
+			drawHandle(gtx, st.Muted)
+			return D{Size: gtx.Constraints.Max}
+		}),
+		layout.Flexed(1, func(gtx C) D {
+			gtx.Constraints.Min.X = gtx.Constraints.Max.X
+			return st.Inset.Layout(gtx, label.Layout)
+		}),
+		layout.Rigid(func(gtx C) D {
+			if !s.Editable {
+				return D{}
+			}
+			return layoutSquareButton(st, gtx, &e.del, "×")
+		}),
+	)
+}
+
+// drawHandle draws a drag handle made of 3 horizontal lines.
+func drawHandle(gtx C, col color.NRGBA) {
+	sz := gtx.Constraints.Max
+	w, gap := sz.X/2, max(1, sz.Y/8)
+	x, y := (sz.X-w)/2, sz.Y/2-gap
+	for i := 0; i < 3; i++ {
+		r := image.Rect(x, y, x+w, y+gtx.Dp(1))
+		paint.FillShape(gtx.Ops, col, clip.Rect(r).Op())
+		y += gap
+	}
+}
+
+// layoutSquareButton lays out a small square button, as tall as the available
+// height, showing sym.
+func layoutSquareButton(s *ListStyle, gtx C, click *widget.Clickable, sym string) D {
+	h := gtx.Constraints.Max.Y
+	gtx.Constraints = layout.Exact(image.Pt(h, h))
+	return click.Layout(gtx, func(gtx C) D {
+		if click.Hovered() {
+			hl := component.WithAlpha(s.Muted, 0x40)
+			paint.FillShape(gtx.Ops, hl, clip.Rect{Max: gtx.Constraints.Max}.Op())
+		}
+		label := material.Label(s.Theme, s.TextSize, sym)
+		label.Color = s.Muted
+		macro := op.Record(gtx.Ops)
+		gtx.Constraints.Min = image.Point{}
+		dims := label.Layout(gtx)
+		call := macro.Stop()
+		off := image.Pt(h-dims.Size.X, h-dims.Size.Y).Div(2)
+		defer op.Offset(off).Push(gtx.Ops).Pop()
+		call.Add(gtx.Ops)
+		return D{Size: image.Pt(h, h)}
+	})
+}
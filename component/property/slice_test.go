@@ -0,0 +1,39 @@
+package property
+
+import (
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func newIntSlice(vals ...int) *Slice[int] {
+	return NewSlice(vals, func(v int) Value[int] { return NewInt(v) })
+}
+
+func TestSlice(t *testing.T) {
+	s := newIntSlice(1, 2, 3)
+
+	check := func(want ...int) {
+		t.Helper()
+		if got := s.Value(); !slices.Equal(got, want) {
+			t.Fatalf("Value() = %v, want %v", got, want)
+		}
+		for i, c := range s.Children() {
+			if got := (*sliceElem[int])(c.Label.(*sliceLabel[int])).idx; got != i {
+				t.Fatalf("child %d has index %d", i, got)
+			}
+		}
+	}
+
+	check(1, 2, 3)
+	s.append(4)
+	check(1, 2, 3, 4)
+	s.move(0, 1)
+	check(2, 1, 3, 4)
+	s.move(3, 2)
+	check(2, 1, 4, 3)
+	s.remove(1)
+	check(2, 4, 3)
+	s.SetValue(nil)
+	check()
+}
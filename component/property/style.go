@@ -0,0 +1,167 @@
+package property
+
+import (
+	"image/color"
+
+	"gioui.org/layout"
+	"gioui.org/text"
+	"gioui.org/unit"
+	"gioui.org/widget/material"
+	"gioui.org/x/component"
+)
+
+var (
+	lightGrey = rgb(0xd3d3d3)
+	darkGrey  = rgb(0xa9a9a9)
+	lightRed  = rgb(0xffcdd2)
+	red       = rgb(0xe53935)
+)
+
+// ListStyle defines the look of a List and of the property widgets it lays
+// out. The List gives its style to the widgets of this package right before
+// laying them out, other widgets only get the theme.
+type ListStyle struct {
+	List  *List
+	Theme *material.Theme
+
+	// Background is the background of the name column, and Stripe the one of
+	// every other row.
+	Background color.NRGBA
+	Stripe     color.NRGBA
+
+	// Selected and Hovered are drawn over the names of the selected and
+	// hovered rows. Selected also highlights suggestions of Autocomplete
+	// properties.
+	Selected color.NRGBA
+	Hovered  color.NRGBA
+
+	// Focused is the color of the border of the row having the keyboard
+	// focus, and of the focused values.
+	Focused color.NRGBA
+
+	// Border is the color of the border of the list and of the separators
+	// between rows.
+	Border color.NRGBA
+
+	// Divider and Handle are the colors of the line separating the columns
+	// and of the handle to resize them.
+	Divider color.NRGBA
+	Handle  color.NRGBA
+
+	// Editable is the background of editable values, ReadOnly the one of
+	// values which can't be edited.
+	Editable color.NRGBA
+	ReadOnly color.NRGBA
+
+	// Error and ErrorBorder are the background and border colors of invalid
+	// values.
+	Error       color.NRGBA
+	ErrorBorder color.NRGBA
+
+	// Muted is the color of secondary content, such as placeholders, the
+	// triangles of dropdowns or the buttons of slices.
+	Muted color.NRGBA
+
+	// Accent marks modified properties.
+	Accent color.NRGBA
+
+	// Sparkline is the color of the history drawn by Live properties.
+	Sparkline color.NRGBA
+
+	// NameFont is the font of property names.
+	NameFont text.Font
+
+	// TextSize is the size of the text of names and values.
+	TextSize unit.Sp
+
+	// Inset is the space around names and values, within their cells.
+	Inset layout.Inset
+}
+
+// DefaultStyle returns the style used by List.Layout, derived from th.
+func DefaultStyle(th *material.Theme, plist *List) ListStyle {
+	return ListStyle{
+		List:        plist,
+		Theme:       th,
+		Background:  th.Bg,
+		Stripe:      th.Bg,
+		Selected:    component.WithAlpha(th.ContrastBg, 0x30),
+		Hovered:     component.WithAlpha(th.ContrastBg, 0x18),
+		Focused:     th.ContrastBg,
+		Border:      th.Fg,
+		Divider:     th.ContrastBg,
+		Handle:      th.ContrastBg,
+		Editable:    th.Bg,
+		ReadOnly:    lightGrey,
+		Error:       lightRed,
+		ErrorBorder: red,
+		Muted:       darkGrey,
+		Accent:      th.ContrastBg,
+		Sparkline:   component.WithAlpha(th.ContrastBg, 0x80),
+		NameFont:    text.Font{Weight: 50},
+		TextSize:    th.TextSize,
+		Inset:       layout.Inset{Top: 1, Right: 4, Bottom: 1, Left: 4},
+	}
+}
+
+// LightStyle returns a style with light colors and striped rows. th is copied
+// with a light palette.
+func LightStyle(th *material.Theme, plist *List) ListStyle {
+	lth := *th
+	lth.Palette = material.Palette{
+		Bg:         rgb(0xffffff),
+		Fg:         rgb(0x202124),
+		ContrastBg: rgb(0x1a73e8),
+		ContrastFg: rgb(0xffffff),
+	}
+	s := DefaultStyle(&lth, plist)
+	s.Stripe = rgb(0xf5f6f7)
+	s.Border = rgb(0xdadce0)
+	s.ReadOnly = rgb(0xf1f3f4)
+	s.Error = rgb(0xfce8e6)
+	s.ErrorBorder = rgb(0xd93025)
+	s.Muted = rgb(0x80868b)
+	return s
+}
+
+// DarkStyle returns a style with dark colors and striped rows. th is copied
+// with a dark palette.
+func DarkStyle(th *material.Theme, plist *List) ListStyle {
+	dth := *th
+	dth.Palette = material.Palette{
+		Bg:         rgb(0x202124),
+		Fg:         rgb(0xe8eaed),
+		ContrastBg: rgb(0x8ab4f8),
+		ContrastFg: rgb(0x202124),
+	}
+	s := DefaultStyle(&dth, plist)
+	s.Stripe = rgb(0x27282b)
+	s.Border = rgb(0x5f6368)
+	s.ReadOnly = rgb(0x303134)
+	s.Error = rgb(0x5c2b29)
+	s.ErrorBorder = rgb(0xf28b82)
+	s.Muted = rgb(0x9aa0a6)
+	return s
+}
+
+// focusBorder returns the border of the values, with the focus color of the
+// style.
+func (s *ListStyle) focusBorder(focused bool) FocusBorderStyle {
+	fb := FocusBorder(s.Theme, focused)
+	fb.Color = s.Focused
+	return fb
+}
+
+// Layout lays out the list with the style.
+func (s ListStyle) Layout(gtx C) D {
+	return s.List.layout(&s, gtx)
+}
+
+// rowBackground returns the background of the name column of the row at index
+// i.
+func (s *ListStyle) rowBackground(i int) color.NRGBA {
+	if i%2 == 1 {
+		return s.Stripe
+	}
+	return s.Background
+}
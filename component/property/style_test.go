@@ -0,0 +1,56 @@
+package property
+
+import (
+	"testing"
+
+	"gioui.org/font/gofont"
+	"gioui.org/widget/material"
+)
+
+func TestStyle(t *testing.T) {
+	th := material.NewTheme(gofont.Collection())
+	bg := th.Bg
+	plist := NewList()
+
+	for _, tt := range []struct {
+		name  string
+		style ListStyle
+	}{
+		{"light", LightStyle(th, plist)},
+		{"dark", DarkStyle(th, plist)},
+	} {
+		s := tt.style
+		if s.List != plist {
+			t.Errorf("%s: style isn't bound to the list", tt.name)
+		}
+		if s.Theme == th {
+			t.Errorf("%s: theme should be a copy", tt.name)
+		}
+		if got := s.rowBackground(0); got != s.Background {
+			t.Errorf("%s: row 0 background = %v, want %v", tt.name, got, s.Background)
+		}
+		if got := s.rowBackground(1); got != s.Stripe {
+			t.Errorf("%s: row 1 background = %v, want %v", tt.name, got, s.Stripe)
+		}
+		if got := s.focusBorder(true).Color; got != s.Focused {
+			t.Errorf("%s: focus border = %v, want %v", tt.name, got, s.Focused)
+		}
+		if s.Sparkline.A == 0 || s.Sparkline.R != s.Theme.ContrastBg.R {
+			t.Errorf("%s: sparkline = %v, want derived from the theme", tt.name, s.Sparkline)
+		}
+	}
+	if th.Bg != bg {
+		t.Errorf("theme has been modified")
+	}
+
+	// Outside of a List, widgets get the default style.
+	var l listed
+	if got := l.listStyle(th); got.Theme != th || got.Muted != darkGrey {
+		t.Errorf("listStyle without list = %+v, want default style", got)
+	}
+	dark := DarkStyle(th, plist)
+	l.setStyle(&dark)
+	if got := l.listStyle(th); got != &dark {
+		t.Errorf("listStyle with list = %p, want %p", got, &dark)
+	}
+}
@@ -1,10 +1,12 @@
 package property
 
 import (
+	"encoding"
 	"image/color"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
-	"gioui.org/layout"
 	"gioui.org/op/clip"
 	"gioui.org/op/paint"
 	"gioui.org/text"
@@ -12,8 +14,6 @@ import (
 	"gioui.org/widget/material"
 )
 
-var lightGrey = rgb(0xd3d3d3)
-
 func rgb(c uint32) color.NRGBA {
 	return argb(0xff000000 | c)
 }
@@ -37,6 +37,20 @@ type Text struct {
 	editor   widget.Editor
 	Editable bool
 	hasFocus bool
+	listed
+
+	// LiveValidation enables the validation of the text while it's being
+	// typed, rather than only when it's committed, if the property value
+	// supports it.
+	LiveValidation bool
+
+	// err is the last validation error, shown to the user until the text is
+	// edited again or successfully committed.
+	err error
+
+	// holdCommit prevents the text from being committed when the editor
+	// loses focus, for when the focus is temporarily given to another widget.
+	holdCommit bool
 }
 
 // NewText creates a Text property and assigns it a value. filter is the list of
@@ -55,60 +69,159 @@ func NewText(val Stringer, filter string) *Text {
 
 func (t *Text) setValue(val Stringer) {
 	t.val = val
-	t.editor.SetText(t.val.String())
+	t.editor.SetText(plainText(t.val))
+	if m, ok := val.(marshaler); ok && m.marshalErr() != nil {
+		t.err = m.marshalErr()
+	}
+}
+
+// marshaler is implemented by values whose conversion to text can fail. The
+// error of the last conversion is shown like validation errors.
+type marshaler interface {
+	marshalErr() error
+}
+
+func (t *Text) editing() bool {
+	return t.editor.Focused()
+}
+
+// plainTexter is implemented by values for which String doesn't return the
+// actual text to edit, such as secrets.
+type plainTexter interface {
+	plainText() string
+}
+
+// plainText returns the text to show in the editor for val.
+func plainText(val Stringer) string {
+	if pt, ok := val.(plainTexter); ok {
+		return pt.plainText()
+	}
+	return val.String()
+}
+
+// labelText returns the text to show when the property is not editable.
+func (t *Text) labelText() string {
+	s := plainText(t.val)
+	if t.editor.Mask != 0 {
+		s = strings.Repeat(string(t.editor.Mask), utf8.RuneCountInString(s))
+	}
+	return s
 }
 
 func (t *Text) value() Stringer {
 	return t.val
 }
 
-func (t *Text) Layout(th *material.Theme, _, gtx C) D {
+// validator is implemented by values which can check a string before it's
+// set, without setting it.
+type validator interface {
+	validate(string) error
+}
+
+// commit sets the value from the edited text. In case of error, the previous
+// value is restored and the error is shown until the next edition.
+func (t *Text) commit() {
+	t.err = t.val.Set(t.editor.Text())
+
+	// Force parsing. This either sets previous valida value or formats
+	// currently entered value.
+	t.setValue(t.val)
+}
+
+// String returns the textual representation of the value.
+func (t *Text) String() string {
+	return t.val.String()
+}
+
+// Set sets the value from its textual representation.
+func (t *Text) Set(s string) error {
+	if err := t.val.Set(s); err != nil {
+		return err
+	}
+	t.err = nil
+	t.setValue(t.val)
+	return nil
+}
+
+// paste sets the value from s as if it had been typed by the user: if s is
+// invalid, the error is shown and returned.
+func (t *Text) paste(s string) error {
+	t.editor.SetText(s)
+	t.commit()
+	return t.err
+}
+
+func (t *Text) editable() bool {
+	return t.Editable
+}
+
+// Err returns the last validation error, or nil if the text is valid.
+func (t *Text) Err() error {
+	return t.err
+}
+
+// border returns the style of the border around the text, which shows both
+// focus and validation errors.
+func (t *Text) border(s *ListStyle) FocusBorderStyle {
+	fb := s.focusBorder(t.hasFocus)
+	if t.err != nil {
+		fb.Focused = true
+		fb.Color = s.ErrorBorder
+	}
+	return fb
+}
+
+func (t *Text) Layout(th *material.Theme, pgtx, gtx C) D {
+	s := t.listStyle(th)
+
 	// Draw background color.
 	rect := clip.Rect{Max: gtx.Constraints.Max}.Op()
-	bgcol := th.Bg
-	if !t.Editable {
-		bgcol = lightGrey
+	for _, e := range t.editor.Events() {
+		// Only consider changes made by the user, that is while the editor is
+		// focused. Programmatic changes happen after the focus has been lost.
+		if _, ok := e.(widget.ChangeEvent); ok && t.editor.Focused() {
+			t.err = nil
+			if v, ok := t.val.(validator); ok && t.LiveValidation {
+				t.err = v.validate(t.editor.Text())
+			}
+		}
 	}
-	paint.FillShape(gtx.Ops, bgcol, rect)
 
 	hadFocus := t.hasFocus
 	t.hasFocus = t.editor.Focused()
-	if hadFocus && !t.hasFocus {
+	if hadFocus && !t.hasFocus && !t.holdCommit {
 		// We've just lost focus, it's the moment to check the
 		// validity of the typed string.
-		if err := t.val.Set(t.editor.Text()); err != nil {
-			// TODO(arl) should we give the user a visual feedback in case of
-			// validation error? maybe animate a red flash. or set a red
-			// background that would quickly fade into the normal background
-			// color
-		}
+		t.commit()
+	}
 
-		// Force parsing. This either sets previous valida value or formats
-		// currently entered value.
-		t.setValue(t.val)
+	bgcol := s.Editable
+	switch {
+	case !t.Editable:
+		bgcol = s.ReadOnly
+	case t.err != nil:
+		bgcol = s.Error
 	}
+	paint.FillShape(gtx.Ops, bgcol, rect)
 
 	// Draw value as an editor or a label depending on whether the property is
 	// editable or not.
-	inset := layout.Inset{Top: 1, Right: 4, Bottom: 1, Left: 4}
-
 	if !t.Editable {
-		label := material.Label(th, th.TextSize, t.val.String())
+		label := material.Label(th, s.TextSize, t.labelText())
 		label.MaxLines = 1
-		label.TextSize = th.TextSize
 		label.Alignment = text.Start
 		label.Color = th.Fg
 
-		return FocusBorder(th, t.hasFocus).Layout(gtx, func(gtx C) D {
-			return inset.Layout(gtx, label.Layout)
+		return t.border(s).Layout(gtx, func(gtx C) D {
+			return s.Inset.Layout(gtx, label.Layout)
 		})
 	}
 
 	ed := material.Editor(th, &t.editor, "")
-	ed.TextSize = th.TextSize
+	ed.TextSize = s.TextSize
 
-	return FocusBorder(th, t.hasFocus).Layout(gtx, func(gtx C) D {
-		return inset.Layout(gtx, ed.Layout)
+	return t.border(s).Layout(gtx, func(gtx C) D {
+		return s.Inset.Layout(gtx, ed.Layout)
 	})
 }
 
@@ -241,26 +354,184 @@ type String struct {
 }
 
 func NewString(val string) *String {
-	return &String{Text: NewText((*stringval)(&val), "")}
+	return &String{Text: NewText(&stringval{val: val}, "")}
 }
 
 func NewStringWithFilter(val, filter string) *String {
-	return &String{Text: NewText((*stringval)(&val), filter)}
+	return &String{Text: NewText(&stringval{val: val}, filter)}
+}
+
+// NewStringWithValidator creates a String property which only accepts the
+// strings for which v returns a nil error.
+func NewStringWithValidator(val string, v Validator) *String {
+	return &String{Text: NewText(&stringval{val: val, check: v}, "")}
+}
+
+// SetValidator sets the validator checking the strings entered by the user.
+// A nil validator accepts any string.
+func (s *String) SetValidator(v Validator) {
+	s.value().(*stringval).check = v
 }
 
 func (s *String) Value() string {
-	return string(*(s.value().(*stringval)))
+	return s.value().(*stringval).val
 }
 
+// SetValue sets the property value. val isn't validated.
 func (s *String) SetValue(val string) {
-	s.setValue((*stringval)(&val))
+	sv := s.value().(*stringval)
+	sv.val = val
+	s.setValue(sv)
 }
 
-type stringval string
+type stringval struct {
+	val   string
+	check Validator
+}
 
 func (s *stringval) Set(str string) error {
-	*s = (stringval)(str)
+	if err := s.validate(str); err != nil {
+		return err
+	}
+	s.val = str
 	return nil
 }
 
-func (s *stringval) String() string { return string(*s) }
+func (s *stringval) validate(str string) error {
+	if s.check == nil {
+		return nil
+	}
+	return s.check(str)
+}
+
+func (s *stringval) String() string { return s.val }
+
+//
+// TextValue
+//
+
+// TextValue is a property holding a value of any type implementing the
+// standard encoding.TextMarshaler and encoding.TextUnmarshaler interfaces,
+// such as net.IP. Types used through pointers, such as *big.Int, are held by
+// TextPointer.
+type TextValue[T any, PT interface {
+	*T
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}] struct {
+	*Text
+}
+
+// NewTextValue creates a TextValue property holding val.
+func NewTextValue[T any, PT interface {
+	*T
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}](val T) *TextValue[T, PT] {
+	return &TextValue[T, PT]{Text: NewText(&textval[T, PT]{val: val}, "")}
+}
+
+func (t *TextValue[T, PT]) Value() T {
+	return t.value().(*textval[T, PT]).val
+}
+
+func (t *TextValue[T, PT]) SetValue(val T) {
+	tv := t.value().(*textval[T, PT])
+	tv.val = val
+	t.setValue(tv)
+}
+
+type textval[T any, PT interface {
+	*T
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}] struct {
+	val T
+	err error
+}
+
+func (t *textval[T, PT]) Set(s string) error {
+	var val T
+	if err := PT(&val).UnmarshalText([]byte(s)); err != nil {
+		return err
+	}
+	t.val = val
+	return nil
+}
+
+func (t *textval[T, PT]) String() string {
+	var b []byte
+	b, t.err = PT(&t.val).MarshalText()
+	return string(b)
+}
+
+func (t *textval[T, PT]) marshalErr() error {
+	return t.err
+}
+
+//
+// TextPointer
+//
+
+// TextPointer is a property holding a pointer to a value implementing the
+// standard encoding.TextMarshaler and encoding.TextUnmarshaler interfaces,
+// such as *big.Int. Setting the property from text allocates a new value, the
+// pointed value is never modified.
+type TextPointer[T any, PT interface {
+	*T
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}] struct {
+	*Text
+}
+
+// NewTextPointer creates a TextPointer property holding val.
+func NewTextPointer[T any, PT interface {
+	*T
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}](val PT) *TextPointer[T, PT] {
+	return &TextPointer[T, PT]{Text: NewText(&textptr[T, PT]{val: val}, "")}
+}
+
+func (t *TextPointer[T, PT]) Value() PT {
+	return t.value().(*textptr[T, PT]).val
+}
+
+func (t *TextPointer[T, PT]) SetValue(val PT) {
+	tp := t.value().(*textptr[T, PT])
+	tp.val = val
+	t.setValue(tp)
+}
+
+type textptr[T any, PT interface {
+	*T
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}] struct {
+	val PT
+	err error
+}
+
+func (t *textptr[T, PT]) Set(s string) error {
+	val := PT(new(T))
+	if err := val.UnmarshalText([]byte(s)); err != nil {
+		return err
+	}
+	t.val = val
+	return nil
+}
+
+func (t *textptr[T, PT]) String() string {
+	if t.val == nil {
+		t.err = nil
+		return ""
+	}
+	var b []byte
+	b, t.err = t.val.MarshalText()
+	return string(b)
+}
+
+func (t *textptr[T, PT]) marshalErr() error {
+	return t.err
+}
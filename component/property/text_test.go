@@ -1,6 +1,9 @@
 package property
 
 import (
+	"errors"
+	"math/big"
+	"net"
 	"testing"
 )
 
@@ -33,3 +36,61 @@ func TestFloat(t *testing.T) {
 		}
 	}
 }
+
+func TestTextValue(t *testing.T) {
+	p := NewTextValue(net.ParseIP("192.0.2.1"))
+	if got := p.val.String(); got != "192.0.2.1" {
+		t.Errorf("got %s want %s", got, "192.0.2.1")
+	}
+	if err := p.val.Set("2001:db8::1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := p.Value(), net.ParseIP("2001:db8::1"); !got.Equal(want) {
+		t.Errorf("got %s want %s", got, want)
+	}
+	if err := p.val.Set("not an ip"); err == nil {
+		t.Errorf("Set succeeded with an invalid IP")
+	}
+	if got, want := p.Value(), net.ParseIP("2001:db8::1"); !got.Equal(want) {
+		t.Errorf("got %s want %s, value shouldn't change on error", got, want)
+	}
+
+	bad := NewTextValue(failMarshal{})
+	if bad.Err() == nil {
+		t.Errorf("MarshalText error should be reported by Err")
+	}
+}
+
+// failMarshal is a value which can't be converted to text.
+type failMarshal struct{}
+
+func (failMarshal) MarshalText() ([]byte, error)  { return nil, errors.New("no text") }
+func (*failMarshal) UnmarshalText(b []byte) error { return nil }
+
+func TestTextPointer(t *testing.T) {
+	v := big.NewInt(42)
+	n := NewTextPointer(v)
+	if got := n.String(); got != "42" {
+		t.Errorf("got %s want %s", got, "42")
+	}
+	if err := n.Set("-7"); err != nil {
+		t.Fatal(err)
+	}
+	if got := n.Value(); got.Cmp(big.NewInt(-7)) != 0 {
+		t.Errorf("got %s want %s", got, "-7")
+	}
+	if v.Int64() != 42 {
+		t.Errorf("pointed value has been modified: %s", v)
+	}
+	if err := n.Set("foo"); err == nil {
+		t.Errorf("Set succeeded with an invalid number")
+	}
+	if got := n.Value(); got.Cmp(big.NewInt(-7)) != 0 {
+		t.Errorf("got %s want %s, value shouldn't change on error", got, "-7")
+	}
+
+	n.SetValue(nil)
+	if got := n.String(); got != "" {
+		t.Errorf("got %q for nil, want empty", got)
+	}
+}
@@ -0,0 +1,117 @@
+package property
+
+import (
+	"reflect"
+	"sync"
+
+	"gioui.org/op"
+)
+
+// Invalidator requests a new frame, app.Window implements it.
+type Invalidator interface {
+	Invalidate()
+}
+
+// editor is implemented by property widgets which can be in the middle of an
+// edition, during which their value mustn't be changed.
+type editor interface {
+	editing() bool
+}
+
+// update is a change of a widget posted with List.Update.
+type update struct {
+	w  Widget
+	do func()
+}
+
+// updateQueue holds the updates posted from any goroutine, until they're
+// applied by the goroutine laying out the list.
+type updateQueue struct {
+	mu      sync.Mutex
+	pending []update
+
+	// deferred are the updates of widgets being edited, only accessed while
+	// laying out the list.
+	deferred []update
+}
+
+// Update schedules f, which changes the value of the widget w, to be called
+// at the start of the next frame by the goroutine laying out the list. Update
+// can be called from any goroutine, then the list Invalidator is called to
+// trigger a new frame.
+//
+// If w is being edited by the user, f is only called once the edition ends,
+// so that the edition isn't clobbered. Updates of a widget replace the ones
+// which haven't been applied yet, so that only the last one is applied, unless
+// the type of the widget isn't comparable.
+func (plist *List) Update(w Widget, f func()) {
+	q := &plist.updates
+	q.mu.Lock()
+	q.pending = coalesce(q.pending, update{w: w, do: f})
+	q.mu.Unlock()
+	if plist.Invalidator != nil {
+		plist.Invalidator.Invalidate()
+	}
+}
+
+// UpdateValue schedules the value of w to be set to val, see List.Update.
+func UpdateValue[T any](plist *List, w Value[T], val T) {
+	plist.Update(w, func() { w.SetValue(val) })
+}
+
+// applyUpdates calls the pending updates of the widgets which aren't being
+// edited.
+func (plist *List) applyUpdates() {
+	q := &plist.updates
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+
+	updates := q.deferred
+	for _, u := range pending {
+		updates = coalesce(updates, u)
+	}
+	q.deferred = nil
+	if len(updates) == 0 {
+		return
+	}
+
+	for _, u := range updates {
+		if e, ok := u.w.(editor); ok && e.editing() {
+			q.deferred = append(q.deferred, u)
+			continue
+		}
+		u.do()
+	}
+}
+
+// coalesce adds u to updates, replacing the update of the same widget if
+// there's one.
+func coalesce(updates []update, u update) []update {
+	for i := range updates {
+		if sameWidget(updates[i].w, u.w) {
+			updates[i] = u
+			return updates
+		}
+	}
+	return append(updates, u)
+}
+
+// sameWidget reports whether a and b are the same widget. Widgets of types
+// which aren't comparable are never the same.
+func sameWidget(a, b Widget) bool {
+	t := reflect.TypeOf(a)
+	return t != nil && t == reflect.TypeOf(b) && t.Comparable() && a == b
+}
+
+// invalidateDeferred requests a new frame if widgets with deferred updates
+// aren't edited anymore.
+func (plist *List) invalidateDeferred(gtx C) {
+	for _, u := range plist.updates.deferred {
+		if e, ok := u.w.(editor); !ok || !e.editing() {
+			op.InvalidateOp{}.Add(gtx.Ops)
+			return
+		}
+	}
+}
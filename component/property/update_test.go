@@ -0,0 +1,172 @@
+package property
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"gioui.org/widget/material"
+)
+
+type countInvalidator struct{ n atomic.Int32 }
+
+func (c *countInvalidator) Invalidate() { c.n.Add(1) }
+
+// editedInt is an Int whose edition is controlled by the test.
+type editedInt struct {
+	*Int
+	edited bool
+}
+
+func (e *editedInt) editing() bool { return e.edited }
+
+func TestListUpdate(t *testing.T) {
+	var inv countInvalidator
+	i := NewInt(0)
+	plist := NewList()
+	plist.Invalidator = &inv
+	plist.Add("int", i)
+
+	var wg sync.WaitGroup
+	for n := 1; n <= 10; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			UpdateValue[int](plist, i, n)
+		}(n)
+	}
+	wg.Wait()
+	if got := inv.n.Load(); got != 10 {
+		t.Errorf("Invalidate called %d times, want 10", got)
+	}
+	if got := i.Value(); got != 0 {
+		t.Fatalf("value = %d before the frame, want 0", got)
+	}
+	plist.applyUpdates()
+	if got := i.Value(); got < 1 || got > 10 {
+		t.Fatalf("value = %d after the frame, want one of the updates", got)
+	}
+
+	e := &editedInt{Int: NewInt(1), edited: true}
+	plist.Add("edited", e)
+	UpdateValue[int](plist, e, 2)
+	UpdateValue[int](plist, e, 3)
+	plist.applyUpdates()
+	if got := e.Value(); got != 1 {
+		t.Fatalf("value = %d while edited, want 1", got)
+	}
+	e.edited = false
+	UpdateValue[int](plist, e, 4)
+	plist.applyUpdates()
+	if got := e.Value(); got != 4 {
+		t.Fatalf("value = %d once edited, want 4", got)
+	}
+	if len(plist.updates.deferred) != 0 {
+		t.Fatalf("%d updates still deferred", len(plist.updates.deferred))
+	}
+}
+
+func TestListUpdateCoalesce(t *testing.T) {
+	e := &editedInt{Int: NewInt(0), edited: true}
+	other := NewInt(0)
+	plist := NewList()
+	plist.Add("edited", e)
+	plist.Add("other", other)
+
+	for n := 1; n <= 100; n++ {
+		UpdateValue[int](plist, e, n)
+		plist.applyUpdates()
+	}
+	UpdateValue[int](plist, other, 1)
+	UpdateValue[int](plist, other, 2)
+	plist.applyUpdates()
+	if got := len(plist.updates.deferred); got != 1 {
+		t.Fatalf("%d updates deferred, want 1", got)
+	}
+	if got := other.Value(); got != 2 {
+		t.Fatalf("other = %d, want 2", got)
+	}
+	e.edited = false
+	plist.applyUpdates()
+	if got := e.Value(); got != 100 {
+		t.Fatalf("value = %d once edited, want 100", got)
+	}
+
+	// Updates of widgets which can't be compared are all kept.
+	w := widgetFunc(func(th *material.Theme, pgtx, gtx C) D { return D{} })
+	var calls int
+	plist.Update(w, func() { calls++ })
+	plist.Update(w, func() { calls++ })
+	plist.applyUpdates()
+	if calls != 2 {
+		t.Fatalf("%d updates of a func widget applied, want 2", calls)
+	}
+}
+
+func TestListUpdateAutocomplete(t *testing.T) {
+	a := NewAutocomplete("", func(string) []string { return []string{"apple"} })
+	plist := NewList()
+	plist.Add("fruit", a)
+
+	// The user browses the suggestions, the editor doesn't have the focus.
+	a.editor.SetText("ap")
+	a.navigating = true
+	plist.Update(a, func() { a.SetValue("banana") })
+	plist.applyUpdates()
+	if got := len(plist.updates.deferred); got != 1 {
+		t.Fatalf("%d updates deferred, want 1", got)
+	}
+	if got := a.editor.Text(); got != "ap" {
+		t.Fatalf("text = %q while browsing suggestions, want %q", got, "ap")
+	}
+
+	a.navigating = false
+	plist.applyUpdates()
+	if got := a.Value(); got != "banana" {
+		t.Fatalf("value = %q once edited, want %q", got, "banana")
+	}
+}
+
+// TestListUpdateRace lays out frames while other goroutines post updates, to
+// be run with -race.
+func TestListUpdateRace(t *testing.T) {
+	var inv countInvalidator
+	i := NewInt(0)
+	plist := NewList()
+	plist.Invalidator = &inv
+	plist.Add("int", i)
+
+	frame := func() { layoutFrame(t, plist, nil) }
+
+	const workers, posts = 4, 100
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 1; n <= posts; n++ {
+				UpdateValue[int](plist, i, n)
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		frame()
+	}
+	frame()
+	if got := i.Value(); got != posts {
+		t.Errorf("value = %d, want the last update %d", got, posts)
+	}
+	if got := inv.n.Load(); got != workers*posts {
+		t.Errorf("Invalidate called %d times, want %d", got, workers*posts)
+	}
+}
@@ -0,0 +1,126 @@
+package property
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// A Validator checks a string entered by the user, returning a non-nil error
+// describing the problem if it's not valid.
+type Validator func(string) error
+
+// AllOf returns a Validator which accepts a string only if all validators
+// accept it. The first error encountered is returned.
+func AllOf(validators ...Validator) Validator {
+	return func(s string) error {
+		for _, v := range validators {
+			if err := v(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// MatchRegexp returns a Validator which accepts strings matching re.
+func MatchRegexp(re *regexp.Regexp) Validator {
+	return func(s string) error {
+		if !re.MatchString(s) {
+			return fmt.Errorf("%q doesn't match %s", s, re)
+		}
+		return nil
+	}
+}
+
+// MinLen returns a Validator which accepts strings of at least n characters.
+func MinLen(n int) Validator {
+	return func(s string) error {
+		if utf8.RuneCountInString(s) < n {
+			return fmt.Errorf("must have at least %d characters", n)
+		}
+		return nil
+	}
+}
+
+// MaxLen returns a Validator which accepts strings of at most n characters.
+func MaxLen(n int) Validator {
+	return func(s string) error {
+		if utf8.RuneCountInString(s) > n {
+			return fmt.Errorf("must have at most %d characters", n)
+		}
+		return nil
+	}
+}
+
+// ValidURL accepts absolute URLs, that is with a scheme and a host.
+func ValidURL(s string) error {
+	u, err := url.Parse(s)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%q is not an absolute URL", s)
+	}
+	return nil
+}
+
+// ValidEmail accepts bare email addresses, such as "gopher@example.com".
+func ValidEmail(s string) error {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return err
+	}
+	if addr.Address != s {
+		return fmt.Errorf("%q is not a bare email address", s)
+	}
+	return nil
+}
+
+// ValidIP accepts IPv4 and IPv6 addresses.
+func ValidIP(s string) error {
+	if net.ParseIP(s) == nil {
+		return fmt.Errorf("%q is not a valid IP address", s)
+	}
+	return nil
+}
+
+// ValidCIDR accepts IP addresses in CIDR notation, such as "192.0.2.0/24".
+func ValidCIDR(s string) error {
+	_, _, err := net.ParseCIDR(s)
+	return err
+}
+
+var hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]*[a-zA-Z0-9])?$`)
+
+// ValidHostname accepts host names as defined by RFC 1123.
+func ValidHostname(s string) error {
+	name := strings.TrimSuffix(s, ".")
+	if name == "" || len(name) > 253 {
+		return fmt.Errorf("%q is not a valid hostname", s)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) > 63 || !hostnameLabel.MatchString(label) {
+			return fmt.Errorf("%q is not a valid hostname", s)
+		}
+	}
+	return nil
+}
+
+// semverRegexp is the regular expression suggested at https://semver.org,
+// with an optional leading 'v'.
+var semverRegexp = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
+
+// ValidSemver accepts semantic versions, such as "1.2.3" or "v1.0.0-rc.1".
+func ValidSemver(s string) error {
+	if !semverRegexp.MatchString(s) {
+		return fmt.Errorf("%q is not a semantic version", s)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package property
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Validator
+		s    string
+		ok   bool
+	}{
+		{"regexp", MatchRegexp(regexp.MustCompile(`^[a-z]+$`)), "abc", true},
+		{"regexp", MatchRegexp(regexp.MustCompile(`^[a-z]+$`)), "ab1", false},
+		{"minlen", MinLen(3), "héé", true},
+		{"minlen", MinLen(3), "hé", false},
+		{"maxlen", MaxLen(2), "hé", true},
+		{"maxlen", MaxLen(2), "héé", false},
+		{"allof", AllOf(MinLen(1), MaxLen(2)), "a", true},
+		{"allof", AllOf(MinLen(1), MaxLen(2)), "", false},
+		{"url", ValidURL, "https://example.com/path?q=1", true},
+		{"url", ValidURL, "example.com/path", false},
+		{"url", ValidURL, "http//example.com", false},
+		{"email", ValidEmail, "gopher@example.com", true},
+		{"email", ValidEmail, "Gopher <gopher@example.com>", false},
+		{"email", ValidEmail, "gopher.example.com", false},
+		{"ip", ValidIP, "192.0.2.1", true},
+		{"ip", ValidIP, "2001:db8::1", true},
+		{"ip", ValidIP, "192.0.2", false},
+		{"cidr", ValidCIDR, "192.0.2.0/24", true},
+		{"cidr", ValidCIDR, "192.0.2.0", false},
+		{"hostname", ValidHostname, "www.example.com", true},
+		{"hostname", ValidHostname, "localhost.", true},
+		{"hostname", ValidHostname, "-bad.example.com", false},
+		{"hostname", ValidHostname, "a..b", false},
+		{"semver", ValidSemver, "1.2.3", true},
+		{"semver", ValidSemver, "v1.0.0-rc.1+build.5", true},
+		{"semver", ValidSemver, "1.2", false},
+		{"semver", ValidSemver, "01.2.3", false},
+	}
+	for _, tt := range tests {
+		err := tt.v(tt.s)
+		if ok := err == nil; ok != tt.ok {
+			t.Errorf("%s(%q) = %v, want ok=%t", tt.name, tt.s, err, tt.ok)
+		}
+	}
+}
+
+func TestStringValidator(t *testing.T) {
+	s := NewStringWithValidator("https://example.com", ValidURL)
+	if err := s.val.Set("not a url"); err == nil {
+		t.Errorf("Set succeeded with an invalid URL")
+	}
+	if got := s.Value(); got != "https://example.com" {
+		t.Errorf("Value() = %q, want previous value", got)
+	}
+	if err := s.val.Set("https://gioui.org"); err != nil {
+		t.Errorf("Set failed with a valid URL: %v", err)
+	}
+	if got := s.Value(); got != "https://gioui.org" {
+		t.Errorf("Value() = %q, want %q", got, "https://gioui.org")
+	}
+}
@@ -8,13 +8,32 @@ import (
 	"gioui.org/io/system"
 	"gioui.org/layout"
 	"gioui.org/op"
+	"gioui.org/widget"
 	"gioui.org/widget/material"
 	"github.com/arl/gioexp/component/property"
+	"golang.org/x/exp/shiny/materialdesign/icons"
 )
 
+func mustIcon(data []byte) *widget.Icon {
+	ic, err := widget.NewIcon(data)
+	if err != nil {
+		panic(err)
+	}
+	return ic
+}
+
 func main() {
 	go func() {
-		dd := property.NewDropDown([]string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"})
+		dd := property.NewDropDownItems([]property.Item{
+			property.Heading("Shapes"),
+			{Label: "circle", Icon: mustIcon(icons.ImageLens), Hint: "C"},
+			{Label: "square", Icon: mustIcon(icons.ImageCropSquare), Hint: "S"},
+			{Label: "triangle", Icon: mustIcon(icons.ImageDetails), Disabled: true},
+			property.Separator(),
+			property.Heading("Letters"),
+			{Label: "a"}, {Label: "b"}, {Label: "c"}, {Label: "d"}, {Label: "e"},
+			{Label: "f"}, {Label: "g"}, {Label: "h"}, {Label: "i"}, {Label: "j"},
+		})
 		w := app.NewWindow()
 		var ops op.Ops
 		for {
@@ -29,6 +48,7 @@ func main() {
 				pgtx := gtx
 				layout.Flex{Axis: layout.Vertical}.Layout(gtx,
 					layout.Rigid(func(gtx layout.Context) layout.Dimensions {
+						gtx.Constraints.Max.Y = gtx.Dp(property.DefaultPropertyHeight)
 						return dd.Layout(th, pgtx, gtx)
 					}))
 				e.Frame(gtx.Ops)
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"gioui.org/app"
+	"gioui.org/font/gofont"
+	"gioui.org/io/system"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/widget/material"
+	"github.com/arl/gioexp/component/property"
+)
+
+// table is a model of many float properties. Widgets are only created for
+// the visible rows, edited values are saved when widgets are released.
+type table struct {
+	vals []float64
+}
+
+func (t *table) Len() int          { return len(t.vals) }
+func (t *table) Name(i int) string { return fmt.Sprintf("row %d", i) }
+
+func (t *table) Widget(i int, recycled property.Widget) property.Widget {
+	if w, ok := recycled.(*property.Float64); ok {
+		w.SetValue(t.vals[i])
+		return w
+	}
+	return property.NewFloat64(t.vals[i])
+}
+
+func (t *table) Release(i int, w property.Widget) {
+	t.vals[i] = w.(*property.Float64).Value()
+}
+
+func main() {
+	t := &table{vals: make([]float64, 50000)}
+	for i := range t.vals {
+		t.vals[i] = float64(i) / 10
+	}
+	plist := property.NewList()
+	plist.Scrollbar = true
+	plist.SetModel(t)
+
+	go func() {
+		w := app.NewWindow(app.Title("Property Model"))
+		th := material.NewTheme(gofont.Collection())
+		var ops op.Ops
+		for {
+			e := <-w.Events()
+			switch e := e.(type) {
+			case system.DestroyEvent:
+				os.Exit(0)
+				return
+			case system.FrameEvent:
+				gtx := layout.NewContext(&ops, e)
+				plist.Layout(th, gtx)
+				e.Frame(gtx.Ops)
+			}
+		}
+	}()
+	app.Main()
+}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"encoding/json"
+	"image"
 	"image/color"
 	"log"
 	"math"
+	"math/big"
+	"net"
 	"os"
+	"strings"
+	"time"
 
 	"gioui.org/app"
 	"gioui.org/font/gofont"
@@ -12,7 +18,6 @@ import (
 	"gioui.org/io/system"
 	"gioui.org/layout"
 	"gioui.org/op"
-	"gioui.org/widget"
 	"gioui.org/widget/material"
 
 	"github.com/arl/gioexp/component/property"
@@ -44,8 +49,8 @@ type UI struct {
 
 	prop5 *property.Uint
 	dd    *property.DropDown
-
-	btn widget.Clickable
+	style *property.DropDown
+	ticks *property.Int
 }
 
 var (
@@ -60,20 +65,112 @@ func NewUI(theme *material.Theme) *UI {
 
 	plist := property.NewList()
 
-	plist.Add("int", property.NewInt(-10))
-	plist.Add("uint", property.NewUInt(123))
+	plist.DescriptionHeight = 60
+	plist.Columns = property.Columns{MinName: 60, MinValue: 100}
+	plist.AddWithDescription("int", "A signed integer, which can be negative.", property.NewInt(-10))
+	plist.AddWithDescription("uint", "An unsigned integer, only accepting positive numbers.", property.NewUInt(123))
 	plist.Add("string", property.NewString("string property"))
 	plist.Add("float64", property.NewFloat64(math.Pi))
 	ui.prop5 = property.NewUInt(27)
 	plist.Add("uint editable", ui.prop5)
 	plist.Add("dropdown", ui.dd)
 	plist.Add("float64(2)", property.NewFloat64(23564.32e12))
+	files := property.NewDropDownProvider(func() []property.Item {
+		var items []property.Item
+		entries, _ := os.ReadDir(".")
+		for _, e := range entries {
+			items = append(items, property.Item{Label: e.Name()})
+		}
+		return items
+	})
+	files.Placeholder = "choose a file…"
+	plist.Add("file", files)
+	plist.Add("color", property.NewAutocomplete("", func(prefix string) []string {
+		var colors []string
+		for _, c := range []string{"black", "blue", "brown", "cyan", "gray", "green", "magenta", "orange", "red", "white", "yellow"} {
+			if strings.HasPrefix(c, prefix) {
+				colors = append(colors, c)
+			}
+		}
+		return colors
+	}))
+	plist.Add("tags", property.NewMultiDropDown([]property.Item{
+		{Label: "red"}, {Label: "green"}, {Label: "blue"}, {Label: "yellow"},
+	}))
+	plist.AddWithDescription("secret", "A password, hidden unless revealed with the eye button.", property.NewSecret("p4ssw0rd"))
+	url := property.NewStringWithValidator("https://gioui.org", property.ValidURL)
+	url.LiveValidation = true
+	plist.Add("url", url)
+	plist.Add("ip", property.NewTextValue(net.ParseIP("192.168.0.1")))
+	googol, _ := new(big.Int).SetString("1"+strings.Repeat("0", 100), 10)
+	plist.Add("googol", property.NewTextPointer(googol))
+	start := time.Now()
+	uptime := property.NewLive(func() string { return time.Since(start).Truncate(time.Second).String() })
+	uptime.Interval = time.Second
+	plist.Add("uptime", uptime)
+	ui.ticks = property.NewInt(0)
+	ui.ticks.Editable = false
+	plist.AddWithDescription("ticks", "Updated every second by a worker goroutine.", ui.ticks)
+	var frames float64
+	fps := property.NewLiveFloat64(func() float64 {
+		frames++
+		return frames / time.Since(start).Seconds()
+	})
+	fps.SetFormat('f', 1)
+	fps.History = 60
+	fps.RowHeight = 50
+	plist.Add("fps", fps)
+	plist.Add("names", property.NewSlice([]string{"alice", "bob"}, func(s string) property.Value[string] {
+		return property.NewString(s)
+	}))
+	plist.Add("weights", property.NewSlice([]float64{0.5, 1, 2.5}, func(f float64) property.Value[float64] {
+		return property.NewFloat64(f)
+	}))
+	plist.Add("points", property.NewSlice([]image.Point{{1, 2}, {3, 4}}, newPointProp))
+	env := property.NewMap(
+		func(k string) property.Value[string] { return property.NewString(k) },
+		func(v string) property.Value[string] { return property.NewString(v) },
+	)
+	env.Less = func(a, b string) bool { return a < b }
+	env.Set("HOME", "/home/gopher")
+	env.Set("GOPATH", "/home/gopher/go")
+	plist.Add("env", env)
+	ui.style = property.NewDropDown([]string{"default", "light", "dark"})
+	plist.Add("style", ui.style)
+	plist.Add("actions", property.NewActions(
+		property.Action{Label: "toggle editable", Do: ui.toggleEditable},
+		property.Action{Label: "reset", Do: func() { ui.prop5.SetValue(27) }},
+		property.Action{Label: "log json", Do: func() {
+			b, err := json.Marshal(ui.plist)
+			if err != nil {
+				log.Println(err)
+				return
+			}
+			log.Printf("%s", b)
+		}},
+		property.Action{Label: "top", Do: func() { ui.plist.ScrollTo(0) }},
+		property.Action{Label: "reset all", Do: func() {
+			if err := ui.plist.ResetAll(); err != nil {
+				log.Println(err)
+			}
+		}},
+	))
+
+	plist.ContextActions = func(path string) []property.Action {
+		return []property.Action{{Label: "Log path", Do: func() { log.Println(path) }}}
+	}
+
+	plist.OnSelect = func(path string) { log.Println("selected", path) }
+	plist.OnError = func(err error) { log.Println("error:", err) }
 
 	ui.plist = plist
 	return ui
 }
 
 func (ui *UI) Run(w *app.Window) error {
+	ui.plist.Invalidator = w
+	go ui.tick()
+
 	var ops op.Ops
 	for e := range w.Events() {
 		switch e := e.(type) {
@@ -94,14 +191,25 @@ func (ui *UI) Run(w *app.Window) error {
 	return nil
 }
 
-func (ui *UI) Layout(gtx C) D {
-	if ui.btn.Clicked() {
-		ui.prop5.Editable = !ui.prop5.Editable
-		ui.dd.Selected = 2
-		ui.prop5.SetValue(234)
+// tick updates the ticks property every second, from its own goroutine.
+func (ui *UI) tick() {
+	for n := 1; ; n++ {
+		time.Sleep(time.Second)
+		property.UpdateValue[int](ui.plist, ui.ticks, n)
 	}
+}
 
+func (ui *UI) toggleEditable() {
+	ui.prop5.Editable = !ui.prop5.Editable
+	ui.dd.Selected = 2
+	ui.prop5.SetValue(234)
+}
+
+func (ui *UI) Layout(gtx C) D {
 	gtx.Constraints.Min = gtx.Constraints.Max
+	// The list is at the top left corner of the window, its popups can
+	// overflow it up to the window bounds.
+	ui.plist.PopupArea = image.Rectangle{Max: gtx.Constraints.Max}
 	return layout.Flex{
 		Axis: layout.Horizontal,
 	}.Layout(gtx,
@@ -112,10 +220,14 @@ func (ui *UI) Layout(gtx C) D {
 			}.Layout(gtx,
 				layout.Rigid(func(gtx C) D {
 					gtx.Constraints.Max.X = 400
-					return ui.plist.Layout(ui.th, gtx)
-				}),
-				layout.Rigid(func(gtx C) D {
-					return material.Button(ui.th, &ui.btn, "toggle editable").Layout(gtx)
+					style := property.DefaultStyle(ui.th, ui.plist)
+					switch ui.style.Selected {
+					case 1:
+						style = property.LightStyle(ui.th, ui.plist)
+					case 2:
+						style = property.DarkStyle(ui.th, ui.plist)
+					}
+					return style.Layout(gtx)
 				}),
 			)
 		}),
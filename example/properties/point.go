@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"image"
+
+	"gioui.org/layout"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"gioui.org/widget/material"
+
+	"github.com/arl/gioexp/component/property"
+)
+
+// pointProp is a property holding an image.Point, which can be expanded to
+// edit its coordinates.
+type pointProp struct {
+	x, y     *property.Int
+	expanded bool
+}
+
+func newPointProp(pt image.Point) property.Value[image.Point] {
+	return &pointProp{x: property.NewInt(pt.X), y: property.NewInt(pt.Y)}
+}
+
+func (p *pointProp) Value() image.Point {
+	return image.Pt(p.x.Value(), p.y.Value())
+}
+
+func (p *pointProp) SetValue(pt image.Point) {
+	p.x.SetValue(pt.X)
+	p.y.SetValue(pt.Y)
+}
+
+func (p *pointProp) Expanded() bool            { return p.expanded }
+func (p *pointProp) SetExpanded(expanded bool) { p.expanded = expanded }
+
+func (p *pointProp) Children() []property.Child {
+	return []property.Child{
+		{Name: "x", Widget: p.x},
+		{Name: "y", Widget: p.y},
+	}
+}
+
+func (p *pointProp) Layout(th *material.Theme, _, gtx C) D {
+	paint.FillShape(gtx.Ops, lightGrey, clip.Rect{Max: gtx.Constraints.Max}.Op())
+	label := material.Label(th, th.TextSize, fmt.Sprint(p.Value()))
+	label.MaxLines = 1
+	inset := layout.Inset{Top: 3, Right: 6, Bottom: 3, Left: 6}
+	inset.Layout(gtx, label.Layout)
+	return D{Size: gtx.Constraints.Max}
+}
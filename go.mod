@@ -6,6 +6,7 @@ require (
 	gioui.org v0.0.0-20221223153152-aa2a948b863a
 	gioui.org/x v0.0.0-20221219202300-e2d994f107e4
 	golang.org/x/exp v0.0.0-20221114191408-850992195362
+	golang.org/x/exp/shiny v0.0.0-20220827204233-334a2380cb91
 )
 
 require (
@@ -14,7 +15,6 @@ require (
 	github.com/benoitkugler/textlayout v0.3.0 // indirect
 	github.com/gioui/uax v0.2.1-0.20220819135011-cda973fac06d // indirect
 	github.com/go-text/typesetting v0.0.0-20221214153724-0399769901d5 // indirect
-	golang.org/x/exp/shiny v0.0.0-20220827204233-334a2380cb91 // indirect
 	golang.org/x/image v0.0.0-20220722155232-062f8c9fd539 // indirect
 	golang.org/x/sys v0.1.0 // indirect
 	golang.org/x/text v0.3.7 // indirect